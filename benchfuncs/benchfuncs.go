@@ -0,0 +1,55 @@
+// Package benchfuncs contains the objective functions used in the labs.
+package benchfuncs
+
+import (
+	"math"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+// CosExpSinLog is the Homework1 function f(x) = cos(exp(x)) / sin(ln(x)).
+func CosExpSinLog(x float64) float64 {
+	numerator := math.Cos(math.Exp(x))
+	denominator := math.Sin(math.Log(x))
+	return numerator / denominator
+}
+
+// Rastrigin is the Rastrigin function with A = 10.
+func Rastrigin(x []float64) float64 {
+	A := 10.0
+	sum := A * float64(len(x))
+	for _, xi := range x {
+		sum += xi*xi - A*math.Cos(2*math.Pi*xi)
+	}
+	return sum
+}
+
+// Forrester is the Forrester et al. function (6x-2)^2 sin(12x-4).
+func Forrester(x float64) float64 {
+	return (6*x - 2) * (6*x - 2) * math.Sin(12*x-4)
+}
+
+// GramacyLee is the Gramacy & Lee function sin(10πx)/(2x) + (x-1)^4.
+func GramacyLee(x float64) float64 {
+	return math.Sin(10*math.Pi*x)/(2*x) + math.Pow(x-1, 4)
+}
+
+// Preset is a one-dimensional objective together with the interval and
+// direction it is usually studied on.
+type Preset struct {
+	F         func(float64) float64
+	Min       float64
+	Max       float64
+	Direction optimize.Direction
+}
+
+// DefaultPreset is the name of the Homework1 preset.
+const DefaultPreset = "cosexp"
+
+// Presets maps names accepted on the command line to one-dimensional
+// objectives.
+var Presets = map[string]Preset{
+	"cosexp":      {F: CosExpSinLog, Min: 2, Max: 4, Direction: optimize.Maximize},
+	"forrester":   {F: Forrester, Min: 0, Max: 1, Direction: optimize.Minimize},
+	"gramacy-lee": {F: GramacyLee, Min: 0.5, Max: 2.5, Direction: optimize.Minimize},
+}
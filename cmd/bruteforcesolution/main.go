@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/knapsack"
+)
+
+func main() {
+	solverName := flag.String("solver", "brute", "exact solver: brute, gray, parallel, bnb, dp, mitm or mitm-stream")
+	limit := flag.Int("limit", knapsack.DefaultCombinationLimit, "combinations kept by mitm-stream, the rest are only counted")
+	output := flag.String("o", "bruteforce_solutions.csv", "results file")
+	flag.Parse()
+
+	solver, ok := knapsack.ExactSolvers[*solverName]
+	if !ok {
+		log.Fatalf("unknown solver %q", *solverName)
+	}
+	if *solverName == "mitm-stream" {
+		solver = func(ctx context.Context, items []knapsack.Item, target int) (knapsack.Solution, error) {
+			return knapsack.StreamMeetInTheMiddle(ctx, items, target, *limit)
+		}
+	}
+
+	itemsList, err := knapsack.ReadItems("knapsack_vectors.csv")
+	if err != nil {
+		log.Fatalf("Error reading items: %v", err)
+	}
+
+	problemsList, err := knapsack.ReadProblems("problems.csv")
+	if err != nil {
+		log.Fatalf("Error reading problems: %v", err)
+	}
+
+	if len(itemsList) != len(problemsList) {
+		log.Fatalf("Mismatched data: %d item vectors vs %d problem sets",
+			len(itemsList), len(problemsList))
+	}
+
+	outputFile, err := os.Create(*output)
+	if err != nil {
+		log.Fatalf("Error creating output file: %v", err)
+	}
+	defer outputFile.Close()
+
+	writer := csv.NewWriter(outputFile)
+	defer writer.Flush()
+
+	writer.Write([]string{
+		"VectorID", "ProblemID", "TargetWeight", "AchievedWeight",
+		"SolutionsCount", "FirstSolutionTime(ms)", "AllSolutionsTime(ms)",
+		"ItemsInSolution", "SolutionIndices",
+	})
+
+	for vectorID := 0; vectorID < len(itemsList); vectorID++ {
+		items := itemsList[vectorID]
+		problems := problemsList[vectorID]
+
+		for _, problem := range problems {
+			fmt.Printf("\n--- Вектор %d, Задача %d ---\n",
+				vectorID+1, problem.ID)
+
+			solution, err := solver(context.Background(), items, problem.Target)
+			if err != nil {
+				log.Fatalf("Error solving vector %d problem %d: %v", vectorID+1, problem.ID, err)
+			}
+			if solution.FirstCombination != nil {
+				fmt.Printf("Первое решение: вес=%d, комбинация=%v\n",
+					solution.FirstWeight, solution.FirstCombination)
+			}
+
+			solutionsStr := ""
+			if len(solution.Combinations) > 0 {
+				var solutions []string
+				for _, comb := range solution.Combinations {
+					solutions = append(solutions, fmt.Sprintf("%v", comb))
+				}
+				solutionsStr = strings.Join(solutions, "; ")
+			}
+
+			err = writer.Write([]string{
+				strconv.Itoa(vectorID + 1),
+				strconv.Itoa(problem.ID),
+				strconv.Itoa(problem.Target),
+				strconv.Itoa(solution.AchievedWeight),
+				strconv.Itoa(solution.Count()),
+				fmt.Sprintf("%.3f", solution.FirstSolutionTime),
+				fmt.Sprintf("%.3f", solution.AllSolutionsTime),
+				strconv.Itoa(len(items)),
+				solutionsStr,
+			})
+
+			if err != nil {
+				log.Printf("Error writing result: %v", err)
+			}
+
+			fmt.Printf(
+				"Достигнуто: %d (Цель:%d), Решений=%d\n"+
+					"Время первого решения: %.3f мс\n"+
+					"Общее время выполнения: %.3f мс\n",
+				solution.AchievedWeight, problem.Target, solution.Count(),
+				solution.FirstSolutionTime,
+				solution.AllSolutionsTime,
+			)
+		}
+	}
+
+	fmt.Printf("\nВсе задачи решены. Результаты сохранены в %s\n", *output)
+}
@@ -0,0 +1,106 @@
+// Command compare runs the real-coded GA and the fish school search on the
+// same problem with the same evaluation budget.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/benchfuncs"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/expr"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/fss"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/ga"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+func main() {
+	expression := flag.String("f", "", "objective as an expression of x1..xn (default: Rastrigin)")
+	dim := flag.Int("dim", 2, "problem dimension")
+	xMin := flag.Float64("min", -5.12, "lower bound of every coordinate")
+	xMax := flag.Float64("max", 5.12, "upper bound of every coordinate")
+	direction := flag.String("dir", "min", "min or max")
+	budget := flag.Int("evals", 15000, "objective evaluations per run")
+	runs := flag.Int("runs", 10, "runs per algorithm")
+	seedFlag := flag.Int64("seed", 0, "random seed of the first run, incremented for each next one; 0 picks one from the clock")
+	flag.Parse()
+
+	if *runs < 1 {
+		log.Fatal("-runs must be positive")
+	}
+
+	dir, err := optimize.ParseDirection(*direction)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	objective := benchfuncs.Rastrigin
+	if *expression != "" {
+		compiled, err := expr.Compile(*expression)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if compiled.Dim() > *dim {
+			log.Fatalf("%q uses x%d, but -dim is %d", *expression, compiled.Dim(), *dim)
+		}
+		objective = compiled.Func()
+	}
+	problem := optimize.NewRealProblem(objective, *dim, *xMin, *xMax, dir)
+
+	gaConfig := ga.DefaultConfig()
+	gaConfig.MaxEvaluations = *budget
+	gaConfig.StagnationLimit = *budget
+
+	fssConfig := fss.DefaultConfig()
+	fssConfig.MaxEvaluations = *budget
+	fssConfig.Iterations = *budget
+
+	// Run r of every algorithm uses seed+r.
+	optimizers := []struct {
+		name string
+		opt  func(seed int64) optimize.Optimizer[[]float64]
+	}{
+		{"GA", func(seed int64) optimize.Optimizer[[]float64] {
+			cfg := gaConfig
+			cfg.Seed = seed
+			return ga.Optimizer{Config: cfg}
+		}},
+		{"FSS", func(seed int64) optimize.Optimizer[[]float64] {
+			cfg := fssConfig
+			cfg.Seed = seed
+			return fss.Optimizer{Config: cfg}
+		}},
+	}
+
+	seed := optimize.ResolveSeed(*seedFlag)
+	fmt.Printf("Seed: %d\n", seed)
+
+	fmt.Printf("%-4s | %14s | %14s | %14s | %10s | %12s\n",
+		"Alg", "Best", "Mean", "Worst", "Evals", "Time")
+	for _, o := range optimizers {
+		best, worst := math.NaN(), math.NaN()
+		sum, evals := 0.0, 0
+		var elapsed time.Duration
+		for r := 0; r < *runs; r++ {
+			res, err := o.opt(seed+int64(r)).Optimize(context.Background(), problem)
+			if err != nil {
+				log.Fatalf("%s: %v", o.name, err)
+			}
+			if r == 0 || dir.Better(res.Value, best) {
+				best = res.Value
+			}
+			if r == 0 || dir.Better(worst, res.Value) {
+				worst = res.Value
+			}
+			sum += res.Value
+			evals += res.Evaluations
+			elapsed += res.Elapsed
+		}
+		n := float64(*runs)
+		fmt.Printf("%-4s | %14.6f | %14.6f | %14.6f | %10.0f | %12v\n",
+			o.name, best, sum/n, worst, float64(evals)/n, elapsed/time.Duration(*runs))
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/benchfuncs"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/expr"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/fss"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+func main() {
+	cfg := fss.DefaultConfig()
+	expression := flag.String("f", "", "objective as an expression of x1..xn, e.g. \"10*n + sum(xi^2 - 10*cos(2*pi*xi))\" (default: Rastrigin)")
+	flag.IntVar(&cfg.Dim, "dim", cfg.Dim, "problem dimension")
+	flag.Float64Var(&cfg.BoundMin, "min", cfg.BoundMin, "lower bound of every coordinate")
+	flag.Float64Var(&cfg.BoundMax, "max", cfg.BoundMax, "upper bound of every coordinate")
+	flag.IntVar(&cfg.Iterations, "iter", cfg.Iterations, "number of iterations")
+	flag.Int64Var(&cfg.Seed, "seed", 0, "random seed; 0 picks one from the clock")
+	flag.Parse()
+
+	objective := benchfuncs.Rastrigin
+	if *expression != "" {
+		compiled, err := expr.Compile(*expression)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if compiled.Dim() > cfg.Dim {
+			log.Fatalf("%q uses x%d, but -dim is %d", *expression, compiled.Dim(), cfg.Dim)
+		}
+		center := make([]float64, cfg.Dim)
+		for i := range center {
+			center[i] = (cfg.BoundMin + cfg.BoundMax) / 2
+		}
+		if _, err := compiled.Eval(center); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+		objective = compiled.Func()
+	}
+
+	cfg.Seed = optimize.ResolveSeed(cfg.Seed)
+	fmt.Println("Seed:", cfg.Seed)
+
+	cfg.OnIteration = func(iteration int, bestFitness float64) {
+		fmt.Printf("%3d | Best fitness: %.6f\n", iteration, bestFitness)
+	}
+
+	startTime := time.Now()
+	bestPos, bestVal, _ := fss.FishSchoolSearch(context.Background(), objective, cfg)
+	endTime := time.Now()
+
+	fmt.Println("\nBest position:", bestPos)
+	fmt.Println("Function value:", bestVal)
+
+	elapsedTime := endTime.Sub(startTime)
+	fmt.Println("Execution time:", elapsedTime)
+}
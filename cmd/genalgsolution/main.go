@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"strconv"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/ga"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/knapsack"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+func main() {
+	selection := flag.String("selection", "tournament:3", "tournament:K, roulette, sus, linear-rank:S, exp-rank:C, truncation:F or boltzmann:T")
+	crossover := flag.String("crossover", "one-point", "one-point, two-point, k-point:K, uniform:P, half-uniform or shuffle")
+	replacement := flag.String("replacement", "generational", "generational, elitism:K, steady-state:N, replace-worst:N, mu+lambda:L or mu,lambda:L")
+	constraint := flag.String("constraint", "distance", "distance, reject, repair, static-penalty:K or adaptive-penalty:K")
+	migration := flag.String("migration", "none", "none or ring|full|random:ISLANDS/INTERVAL/MIGRANTS, e.g. ring:4/10/2")
+	workers := flag.Int("workers", 1, "goroutines that breed and evaluate offspring")
+	seedFlag := flag.Int64("seed", 0, "random seed; 0 picks one from the clock")
+	flag.Parse()
+
+	// Every run gets its own seed, drawn in turn from seed and recorded
+	// with its result. seed itself starts the results file: -seed with it
+	// repeats the whole experiment.
+	seed := optimize.ResolveSeed(*seedFlag)
+	rng := rand.New(rand.NewSource(seed))
+	fmt.Printf("Seed: %d\n", seed)
+
+	itemsList, err := knapsack.ReadItems("knapsack_vectors.csv")
+	if err != nil {
+		log.Fatal("Error reading items:", err)
+	}
+
+	problemsList, err := knapsack.ReadProblems("problems.csv")
+	if err != nil {
+		log.Fatal("Error reading problems:", err)
+	}
+
+	bruteTimes, err := knapsack.ReadBruteTimes("knapsack_solutions_ms.csv")
+	if err != nil {
+		log.Println("Warning: could not read brute times, using default values:", err)
+		bruteTimes = make([][]float64, len(itemsList))
+		for i := range bruteTimes {
+			bruteTimes[i] = make([]float64, knapsack.ProblemsPerVector)
+		}
+	}
+
+	config := knapsack.DefaultGAConfig()
+	config.Workers = *workers
+	if config.Selection, err = ga.ParseSelection(*selection); err != nil {
+		log.Fatal(err)
+	}
+	if config.Crossover, err = knapsack.ParseCrossover(*crossover); err != nil {
+		log.Fatal(err)
+	}
+	if config.Replacement, err = ga.ParseReplacement(*replacement); err != nil {
+		log.Fatal(err)
+	}
+	if config.Constraint, err = knapsack.ParseConstraint(*constraint); err != nil {
+		log.Fatal(err)
+	}
+	if config.Migration, err = ga.ParseMigration(*migration); err != nil {
+		log.Fatal(err)
+	}
+
+	resultsFile, err := os.Create("ga_solutions.csv")
+	if err != nil {
+		log.Fatal("Error creating results file:", err)
+	}
+	defer resultsFile.Close()
+	if err := knapsack.WriteSeed(resultsFile, seed); err != nil {
+		log.Fatal("Error writing results file:", err)
+	}
+
+	writer := csv.NewWriter(resultsFile)
+	defer writer.Flush()
+
+	header := []string{
+		"VectorID", "ProblemID", "TargetWeight", "AchievedWeight",
+		"Fitness", "Generations", "Evaluations", "GeneUpdates", "DurationMs", "TerminationReason",
+		"Selection", "Crossover", "Replacement", "Constraint", "Migration", "Seed", "SolutionItems",
+	}
+	writer.Write(header)
+
+	for vectorID, items := range itemsList {
+		problems := problemsList[vectorID]
+		for problemID, problem := range problems {
+			problem.BruteTimeMs = bruteTimes[vectorID][problemID]
+			config.Seed = rng.Int63()
+
+			bestSolution, report := knapsack.GeneticAlgorithm(context.Background(), items, problem, config)
+
+			result := knapsack.GAResult{
+				VectorID:          vectorID + 1,
+				ProblemID:         problem.ID,
+				TargetWeight:      problem.Target,
+				AchievedWeight:    bestSolution.Weight,
+				Fitness:           bestSolution.Fitness,
+				Generations:       report.Generations,
+				Evaluations:       report.Evaluations,
+				GeneUpdates:       report.GeneUpdates,
+				DurationMs:        report.WallTime.Seconds() * 1000,
+				TerminationReason: report.Reason,
+				Selection:         config.Selection.String(),
+				Crossover:         config.Crossover.String(),
+				Replacement:       config.Replacement.String(),
+				Constraint:        config.Constraint.String(),
+				Migration:         config.Migration.String(),
+				Seed:              config.Seed,
+				BestSolution:      knapsack.SolutionIndices(bestSolution, items),
+			}
+
+			record := []string{
+				strconv.Itoa(result.VectorID),
+				strconv.Itoa(result.ProblemID),
+				strconv.Itoa(result.TargetWeight),
+				strconv.Itoa(result.AchievedWeight),
+				strconv.Itoa(result.Fitness),
+				strconv.Itoa(result.Generations),
+				strconv.Itoa(result.Evaluations),
+				strconv.Itoa(result.GeneUpdates),
+				fmt.Sprintf("%.3f", result.DurationMs),
+				result.TerminationReason.String(),
+				result.Selection,
+				result.Crossover,
+				result.Replacement,
+				result.Constraint,
+				result.Migration,
+				strconv.FormatInt(result.Seed, 10),
+				knapsack.FormatSolution(result.BestSolution),
+			}
+			writer.Write(record)
+
+			fmt.Printf("Вектор %d Задача %d: , Достигнуто: %d (Целевой вес: %d), Фитнесс-функция = %d, Поколений: %d, Вычислений: %d, Время работы: %.2fms, Причина остановки: %s\n",
+				result.VectorID, result.ProblemID, result.AchievedWeight, result.TargetWeight,
+				result.Fitness, result.Generations, result.Evaluations, result.DurationMs, result.TerminationReason)
+		}
+	}
+}
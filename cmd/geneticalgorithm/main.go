@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/benchfuncs"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/expr"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/ga"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+// fileConfig is the JSON config accepted by -config. Omitted fields keep
+// the values of the chosen preset and ga.DefaultConfig.
+type fileConfig struct {
+	Function        string   `json:"function"`
+	Expression      string   `json:"expression"`
+	Dim             int      `json:"dim"`
+	Min             *float64 `json:"min"`
+	Max             *float64 `json:"max"`
+	Direction       string   `json:"direction"`
+	PopulationSize  int      `json:"population_size"`
+	Selection       string   `json:"selection"`
+	Crossover       string   `json:"crossover"`
+	Mutation        string   `json:"mutation"`
+	Replacement     string   `json:"replacement"`
+	CrossoverRate   *float64 `json:"crossover_rate"`
+	MutationRate    *float64 `json:"mutation_rate"`
+	StagnationLimit int      `json:"stagnation_limit"`
+	Seed            int64    `json:"seed"`
+}
+
+func readFileConfig(path string) (fileConfig, error) {
+	var fc fileConfig
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fc, err
+	}
+	err = json.Unmarshal(data, &fc)
+	return fc, err
+}
+
+func presetNames() string {
+	names := make([]string, 0, len(benchfuncs.Presets))
+	for name := range benchfuncs.Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func formatPoint(x []float64) string {
+	coords := make([]string, len(x))
+	for i, v := range x {
+		coords[i] = fmt.Sprintf("%.10f", v)
+	}
+	return strings.Join(coords, ", ")
+}
+
+func main() {
+	configPath := flag.String("config", "", "JSON file with the function and GA parameters")
+	funcName := flag.String("func", benchfuncs.DefaultPreset, "objective preset: "+presetNames())
+	expression := flag.String("f", "", "objective as an expression of x1..xn, e.g. \"cos(exp(x))/sin(log(x))\"; overrides -func")
+	dim := flag.Int("dim", 1, "problem dimension; presets are one-dimensional, so -dim > 1 requires -f")
+	xMin := flag.Float64("min", 0, "lower bound of every coordinate (default: preset bound)")
+	xMax := flag.Float64("max", 0, "upper bound of every coordinate (default: preset bound)")
+	direction := flag.String("dir", "", "min or max (default: preset direction)")
+	popSize := flag.Int("pop", 0, "population size")
+	crossover := flag.String("crossover", "", "arithmetic:L, uniform-arithmetic, blx:A, sbx:ETA or heuristic")
+	mutation := flag.String("mutation", "", "uniform:W, gaussian:S, polynomial:ETA, non-uniform:B, self-adaptive:S0 or one-fifth:S0")
+	replacement := flag.String("replacement", "", "generational, elitism:K, steady-state:N, replace-worst:N, mu+lambda:L or mu,lambda:L")
+	selection := flag.String("selection", "", "tournament:K, roulette, sus, linear-rank:S, exp-rank:C, truncation:F or boltzmann:T")
+	crossProb := flag.Float64("cross", 0, "crossover probability")
+	mutProb := flag.Float64("mut", 0, "mutation probability")
+	stagnation := flag.Int("stagnation", 0, "generations without improvement before stopping")
+	migration := flag.String("migration", "none", "none or ring|full|random:ISLANDS/INTERVAL/MIGRANTS, e.g. ring:4/10/2")
+	seed := flag.Int64("seed", 0, "random seed; 0 picks one from the clock")
+	flag.Parse()
+
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	var fc fileConfig
+	if *configPath != "" {
+		var err error
+		if fc, err = readFileConfig(*configPath); err != nil {
+			log.Fatalf("Error reading config: %v", err)
+		}
+	}
+
+	name := benchfuncs.DefaultPreset
+	if fc.Function != "" {
+		name = fc.Function
+	}
+	if set["func"] {
+		name = *funcName
+	}
+	preset, ok := benchfuncs.Presets[name]
+	if !ok {
+		log.Fatalf("Unknown function %q, available: %s", name, presetNames())
+	}
+
+	n := 1
+	if fc.Dim > 0 {
+		n = fc.Dim
+	}
+	if set["dim"] {
+		n = *dim
+	}
+
+	source := fc.Expression
+	if set["f"] {
+		source = *expression
+	}
+	fitness := func(x []float64) float64 { return preset.F(x[0]) }
+	var compiled *expr.Expr
+	if source != "" {
+		var err error
+		if compiled, err = expr.Compile(source); err != nil {
+			log.Fatal(err)
+		}
+		if compiled.Dim() > n {
+			log.Fatalf("%q uses x%d, but the dimension is %d", source, compiled.Dim(), n)
+		}
+		fitness = compiled.Func()
+	} else if n != 1 {
+		log.Fatalf("Preset %q is one-dimensional, use -f for dimension %d", name, n)
+	}
+
+	cfg := ga.DefaultConfig()
+	xLow, xHigh := preset.Min, preset.Max
+	cfg.Direction = preset.Direction
+
+	if fc.Min != nil {
+		xLow = *fc.Min
+	}
+	if fc.Max != nil {
+		xHigh = *fc.Max
+	}
+	if fc.Direction != "" {
+		dir, err := optimize.ParseDirection(fc.Direction)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.Direction = dir
+	}
+	if fc.PopulationSize > 0 {
+		cfg.PopulationSize = fc.PopulationSize
+	}
+	if fc.Selection != "" {
+		sel, err := ga.ParseSelection(fc.Selection)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.Selection = sel
+	}
+	if fc.Crossover != "" {
+		cross, err := ga.ParseCrossover(fc.Crossover)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.Crossover = cross
+	}
+	if fc.Mutation != "" {
+		mut, err := ga.ParseMutation(fc.Mutation)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.Mutation = mut
+	}
+	if fc.Replacement != "" {
+		repl, err := ga.ParseReplacement(fc.Replacement)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.Replacement = repl
+	}
+	if fc.CrossoverRate != nil {
+		cfg.CrossoverRate = *fc.CrossoverRate
+	}
+	if fc.MutationRate != nil {
+		cfg.MutationRate = *fc.MutationRate
+	}
+	if fc.StagnationLimit > 0 {
+		cfg.StagnationLimit = fc.StagnationLimit
+	}
+	cfg.Seed = fc.Seed
+
+	if set["min"] {
+		xLow = *xMin
+	}
+	if set["max"] {
+		xHigh = *xMax
+	}
+	if set["dir"] {
+		dir, err := optimize.ParseDirection(*direction)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.Direction = dir
+	}
+	if set["pop"] {
+		cfg.PopulationSize = *popSize
+	}
+	if set["selection"] {
+		sel, err := ga.ParseSelection(*selection)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.Selection = sel
+	}
+	if set["crossover"] {
+		cross, err := ga.ParseCrossover(*crossover)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.Crossover = cross
+	}
+	if set["mutation"] {
+		mut, err := ga.ParseMutation(*mutation)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.Mutation = mut
+	}
+	if set["replacement"] {
+		repl, err := ga.ParseReplacement(*replacement)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.Replacement = repl
+	}
+	if set["cross"] {
+		cfg.CrossoverRate = *crossProb
+	}
+	if set["mut"] {
+		cfg.MutationRate = *mutProb
+	}
+	if set["stagnation"] {
+		cfg.StagnationLimit = *stagnation
+	}
+	if set["seed"] {
+		cfg.Seed = *seed
+	}
+	if set["migration"] {
+		m, err := ga.ParseMigration(*migration)
+		if err != nil {
+			log.Fatal(err)
+		}
+		cfg.Migration = m
+	}
+
+	if xLow >= xHigh {
+		log.Fatalf("Invalid interval [%g; %g]", xLow, xHigh)
+	}
+	cfg.Lower, cfg.Upper = ga.Bounds(n, xLow, xHigh)
+
+	if compiled != nil {
+		center := make([]float64, n)
+		for i := range center {
+			center[i] = (xLow + xHigh) / 2
+		}
+		if _, err := compiled.Eval(center); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+
+	cfg.Seed = optimize.ResolveSeed(cfg.Seed)
+	fmt.Println("Seed:", cfg.Seed)
+	startTime := time.Now()
+
+	cfg.OnGeneration = func(generation int, best []float64, value float64) {
+		fmt.Printf("Поколение %d: x = %s; f(x) = %.10f\n", generation, formatPoint(best), value)
+	}
+	result, _ := ga.Run(context.Background(), fitness, cfg)
+
+	workTime := time.Since(startTime)
+	fmt.Printf("Лучшее найденное решение: а(%s) = %.10f\n", formatPoint(result.Best), result.Value)
+	fmt.Printf("Время работы алгоритма: %d мс\n", workTime.Microseconds())
+}
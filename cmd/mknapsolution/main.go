@@ -0,0 +1,123 @@
+// Command mknapsolution runs the knapsack GA on multidimensional knapsack
+// instances in the OR-Library mknap format and reports the gap to their
+// known optima.
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"strconv"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/ga"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/knapsack"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+func main() {
+	input := flag.String("f", "mknap1.txt", "instance file in the OR-Library mknap format")
+	output := flag.String("o", "mknap_solutions.csv", "results file")
+	selection := flag.String("selection", "tournament:3", "tournament:K, roulette, sus, linear-rank:S, exp-rank:C, truncation:F or boltzmann:T")
+	crossover := flag.String("crossover", "uniform", "one-point, two-point, k-point:K, uniform:P, half-uniform or shuffle")
+	replacement := flag.String("replacement", "elitism:1", "generational, elitism:K, steady-state:N, replace-worst:N, mu+lambda:L or mu,lambda:L")
+	constraint := flag.String("constraint", "repair", "reject ranks infeasible solutions last, repair uses the pseudo-utility heuristic, distance, static-penalty:K and adaptive-penalty:K subtract a penalty for the excess weight from the value")
+	pop := flag.Int("pop", 100, "population size")
+	generations := flag.Int("gens", 1000, "maximum number of generations")
+	stagnation := flag.Int("stagnation", 200, "generations without improvement before stopping")
+	mutation := flag.Float64("mut", 0.01, "mutation rate per gene")
+	workers := flag.Int("workers", 1, "goroutines that breed and evaluate offspring")
+	seedFlag := flag.Int64("seed", 0, "random seed; 0 picks one from the clock")
+	flag.Parse()
+
+	// Every run gets its own seed, drawn in turn from seed and recorded
+	// with its result. seed itself starts the results file: -seed with it
+	// repeats the whole experiment.
+	seed := optimize.ResolveSeed(*seedFlag)
+	rng := rand.New(rand.NewSource(seed))
+	fmt.Printf("Seed: %d\n", seed)
+
+	instances, err := knapsack.ReadMKNAP(*input)
+	if err != nil {
+		log.Fatal("Error reading instances:", err)
+	}
+
+	config := knapsack.DefaultGAConfig()
+	config.Workers = *workers
+	config.PopulationSize = *pop
+	config.MaxGenerations = *generations
+	config.MaxNoImprovement = *stagnation
+	config.MutationRate = *mutation
+	if config.Selection, err = ga.ParseSelection(*selection); err != nil {
+		log.Fatal(err)
+	}
+	if config.Crossover, err = knapsack.ParseCrossover(*crossover); err != nil {
+		log.Fatal(err)
+	}
+	if config.Replacement, err = ga.ParseReplacement(*replacement); err != nil {
+		log.Fatal(err)
+	}
+	if config.Constraint, err = knapsack.ParseConstraint(*constraint); err != nil {
+		log.Fatal(err)
+	}
+
+	resultsFile, err := os.Create(*output)
+	if err != nil {
+		log.Fatal("Error creating results file:", err)
+	}
+	defer resultsFile.Close()
+	if err := knapsack.WriteSeed(resultsFile, seed); err != nil {
+		log.Fatal("Error writing results file:", err)
+	}
+
+	writer := csv.NewWriter(resultsFile)
+	defer writer.Flush()
+
+	writer.Write([]string{
+		"Instance", "Items", "Constraints", "Optimum", "Value", "Gap(%)", "Feasible",
+		"Generations", "Evaluations", "DurationMs", "TerminationReason",
+		"Selection", "Crossover", "Replacement", "Constraint", "Seed",
+	})
+
+	for _, instance := range instances {
+		problem := instance.Problem
+		config.Seed = rng.Int63()
+		best, report := knapsack.ValueGA(context.Background(), instance.Items, problem, config)
+
+		// The value of an infeasible solution may exceed the optimum, so
+		// it has no meaningful gap.
+		gap := ""
+		if g, ok := problem.Gap(best.Value); ok && best.Feasible() {
+			gap = fmt.Sprintf("%.3f", g)
+		}
+		writer.Write([]string{
+			strconv.Itoa(problem.ID),
+			strconv.Itoa(len(instance.Items)),
+			strconv.Itoa(len(problem.Capacities)),
+			strconv.Itoa(problem.Optimum),
+			strconv.Itoa(best.Value),
+			gap,
+			strconv.FormatBool(best.Feasible()),
+			strconv.Itoa(report.Generations),
+			strconv.Itoa(report.Evaluations),
+			fmt.Sprintf("%.3f", report.WallTime.Seconds()*1000),
+			report.Reason.String(),
+			config.Selection.String(),
+			config.Crossover.String(),
+			config.Replacement.String(),
+			config.Constraint.String(),
+			strconv.FormatInt(config.Seed, 10),
+		})
+
+		shownGap := "-"
+		if gap != "" {
+			shownGap = gap + "%"
+		}
+		fmt.Printf("Задача %d (n=%d, m=%d): Оптимум = %d, ГА = %d, Разрыв = %s, Допустимо: %t, Поколений: %d, Причина остановки: %s\n",
+			problem.ID, len(instance.Items), len(problem.Capacities), problem.Optimum,
+			best.Value, shownGap, best.Feasible(), report.Generations, report.Reason)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/knapsack"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+const (
+	minItems   = 2
+	maxItems   = 12
+	numTasks   = knapsack.ProblemsPerVector
+	totalItems = 24
+)
+
+func main() {
+	seedFlag := flag.Int64("seed", 0, "random seed; 0 picks one from the clock")
+	flag.Parse()
+
+	vectors, err := knapsack.ReadVectors("knapsack_vectors.csv")
+	if err != nil {
+		log.Println("Ошибка при чтении файла:", err)
+		return
+	}
+
+	seed := optimize.ResolveSeed(*seedFlag)
+	rng := rand.New(rand.NewSource(seed))
+	fmt.Printf("Seed: %d\n\n", seed)
+
+	for i, vector := range vectors {
+		fmt.Printf("Вектор %d: %v\n", i+1, vector)
+		for taskNum := 1; taskNum <= numTasks; taskNum++ {
+			target, selectedItems := knapsack.GenerateTask(rng, vector, minItems, maxItems)
+			if target == -1 {
+				fmt.Printf("  Задача %d: Не удалось найти подходящий target_weight\n", taskNum)
+				continue
+			}
+			selectedItemsCount := len(selectedItems)
+			percentage := float64(selectedItemsCount) / float64(totalItems)
+			fmt.Printf("  Задача %d: Целевой вес = %d, Выбранные предметы: %v, Доля: %.2f\n",
+				taskNum, target, selectedItems, percentage)
+		}
+		fmt.Println()
+	}
+}
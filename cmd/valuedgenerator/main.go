@@ -0,0 +1,65 @@
+// Command valuedgenerator writes random general 0/1 knapsack instances:
+// item vectors with values and weights, and capacities as fractions of
+// the total weight.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/knapsack"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+func main() {
+	numVectors := flag.Int("vectors", 10, "number of item vectors")
+	length := flag.Int("items", 20, "items per vector")
+	dims := flag.Int("dims", 1, "weight dimensions per item")
+	maxWeight := flag.Int("max-weight", 1000, "largest item weight")
+	maxValue := flag.Int("max-value", 1000, "largest item value")
+	ratiosFlag := flag.String("ratios", "0.25,0.5,0.75", "capacities as comma-separated fractions of the total weight")
+	itemsFile := flag.String("o", "knapsack_items.csv", "item vectors output file")
+	problemsFile := flag.String("p", "knapsack_capacities.csv", "capacities output file")
+	seedFlag := flag.Int64("seed", 0, "random seed; 0 picks one from the clock")
+	flag.Parse()
+
+	var ratios []float64
+	for _, s := range strings.Split(*ratiosFlag, ",") {
+		r, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil || r <= 0 {
+			log.Fatalf("invalid ratio %q", s)
+		}
+		ratios = append(ratios, r)
+	}
+	if *numVectors < 1 || *length < 1 || *dims < 1 {
+		log.Fatal("-vectors, -items and -dims must be positive")
+	}
+
+	seed := optimize.ResolveSeed(*seedFlag)
+	rng := rand.New(rand.NewSource(seed))
+
+	vectors := make([][]knapsack.ValuedItem, *numVectors)
+	problems := make([][]knapsack.ValueProblem, *numVectors)
+	for v := range vectors {
+		vectors[v] = knapsack.GenerateValuedItems(rng, *length, *dims, *maxWeight, *maxValue)
+		for i, r := range ratios {
+			problems[v] = append(problems[v], knapsack.ValueProblem{
+				ID:         i + 1,
+				Capacities: knapsack.GenerateCapacities(vectors[v], r),
+			})
+		}
+	}
+
+	if err := knapsack.WriteValuedItems(*itemsFile, vectors, seed); err != nil {
+		log.Fatal("Ошибка при записи предметов:", err)
+	}
+	if err := knapsack.WriteValueProblems(*problemsFile, problems, seed); err != nil {
+		log.Fatal("Ошибка при записи вместимостей:", err)
+	}
+	fmt.Printf("Векторов: %d, задач: %d, seed: %d. Результаты сохранены в %s и %s\n",
+		len(vectors), len(vectors)*len(ratios), seed, *itemsFile, *problemsFile)
+}
@@ -0,0 +1,124 @@
+// Command valuedsolution solves the general 0/1 knapsack instances written
+// by valuedgenerator with the brute force and the genetic algorithm and
+// compares the values they reach.
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/ga"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/knapsack"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+func main() {
+	itemsFile := flag.String("items", "knapsack_items.csv", "item vectors")
+	problemsFile := flag.String("capacities", "knapsack_capacities.csv", "capacities")
+	selection := flag.String("selection", "tournament:3", "tournament:K, roulette, sus, linear-rank:S, exp-rank:C, truncation:F or boltzmann:T")
+	crossover := flag.String("crossover", "one-point", "one-point, two-point, k-point:K, uniform:P, half-uniform or shuffle")
+	replacement := flag.String("replacement", "generational", "generational, elitism:K, steady-state:N, replace-worst:N, mu+lambda:L or mu,lambda:L")
+	constraint := flag.String("constraint", "reject", "reject ranks infeasible solutions last, repair uses the pseudo-utility heuristic, distance, static-penalty:K and adaptive-penalty:K subtract a penalty for the excess weight from the value")
+	workers := flag.Int("workers", 1, "goroutines that breed and evaluate offspring")
+	seedFlag := flag.Int64("seed", 0, "random seed; 0 picks one from the clock")
+	flag.Parse()
+
+	// Every run gets its own seed, drawn in turn from seed and recorded
+	// with its result. seed itself starts the results file: -seed with it
+	// repeats the whole experiment.
+	seed := optimize.ResolveSeed(*seedFlag)
+	rng := rand.New(rand.NewSource(seed))
+	fmt.Printf("Seed: %d\n", seed)
+
+	itemsList, err := knapsack.ReadValuedItems(*itemsFile)
+	if err != nil {
+		log.Fatal("Error reading items:", err)
+	}
+	problemsList, err := knapsack.ReadValueProblems(*problemsFile)
+	if err != nil {
+		log.Fatal("Error reading capacities:", err)
+	}
+	if len(itemsList) != len(problemsList) {
+		log.Fatalf("Mismatched data: %d item vectors vs %d problem sets", len(itemsList), len(problemsList))
+	}
+
+	config := knapsack.DefaultGAConfig()
+	config.Workers = *workers
+	if config.Selection, err = ga.ParseSelection(*selection); err != nil {
+		log.Fatal(err)
+	}
+	if config.Crossover, err = knapsack.ParseCrossover(*crossover); err != nil {
+		log.Fatal(err)
+	}
+	if config.Replacement, err = ga.ParseReplacement(*replacement); err != nil {
+		log.Fatal(err)
+	}
+	if config.Constraint, err = knapsack.ParseConstraint(*constraint); err != nil {
+		log.Fatal(err)
+	}
+
+	resultsFile, err := os.Create("valued_solutions.csv")
+	if err != nil {
+		log.Fatal("Error creating results file:", err)
+	}
+	defer resultsFile.Close()
+	if err := knapsack.WriteSeed(resultsFile, seed); err != nil {
+		log.Fatal("Error writing results file:", err)
+	}
+
+	writer := csv.NewWriter(resultsFile)
+	defer writer.Flush()
+
+	writer.Write([]string{
+		"VectorID", "ProblemID", "Capacities", "BruteValue", "BruteTimeMs",
+		"GAValue", "GAWeights", "Feasible", "Generations", "Evaluations", "GADurationMs",
+		"TerminationReason", "Constraint", "Seed", "SolutionItems",
+	})
+
+	for vectorID, items := range itemsList {
+		for _, problem := range problemsList[vectorID] {
+			solution, _ := knapsack.BruteForceValue(context.Background(), items, problem.Capacities)
+			problem.BruteTimeMs = solution.AllSolutionsTime
+			config.Seed = rng.Int63()
+
+			best, report := knapsack.ValueGA(context.Background(), items, problem, config)
+
+			writer.Write([]string{
+				strconv.Itoa(vectorID + 1),
+				strconv.Itoa(problem.ID),
+				joinInts(problem.Capacities),
+				strconv.Itoa(solution.AchievedValue),
+				fmt.Sprintf("%.3f", solution.AllSolutionsTime),
+				strconv.Itoa(best.Value),
+				joinInts(best.Weights),
+				strconv.FormatBool(best.Feasible()),
+				strconv.Itoa(report.Generations),
+				strconv.Itoa(report.Evaluations),
+				fmt.Sprintf("%.3f", report.WallTime.Seconds()*1000),
+				report.Reason.String(),
+				config.Constraint.String(),
+				strconv.FormatInt(config.Seed, 10),
+				knapsack.FormatSolution(knapsack.ValueSolutionIndices(best, items)),
+			})
+
+			fmt.Printf("Вектор %d Задача %d: Перебор = %d (%.2fms), ГА = %d (%.2fms), Допустимо: %t, Причина остановки: %s\n",
+				vectorID+1, problem.ID, solution.AchievedValue, solution.AllSolutionsTime,
+				best.Value, report.WallTime.Seconds()*1000, best.Feasible(), report.Reason)
+		}
+	}
+}
+
+func joinInts(values []int) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.Itoa(v)
+	}
+	return strings.Join(s, " ")
+}
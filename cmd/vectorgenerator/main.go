@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/knapsack"
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+const (
+	vectorLength = 24
+	numVectors   = 50
+)
+
+func main() {
+	seedFlag := flag.Int64("seed", 0, "random seed; 0 picks one from the clock")
+	flag.Parse()
+
+	seed := optimize.ResolveSeed(*seedFlag)
+	rng := rand.New(rand.NewSource(seed))
+	fmt.Printf("Seed: %d\n", seed)
+
+	maxValue := int(math.Pow(2, vectorLength/1.4))
+	vectors := knapsack.GenerateUniqueVectors(rng, numVectors, vectorLength, maxValue)
+
+	for i := 0; i < len(vectors); i++ {
+		fmt.Printf("%d: %v\n", i+1, vectors[i])
+	}
+}
@@ -0,0 +1,261 @@
+// Package expr compiles arithmetic expressions over x1..xn into objective
+// functions.
+//
+// Expressions may use numbers, the operators + - * / ^, parentheses, the
+// functions sin, cos, tan, exp, log (ln), sqrt and abs, the constants pi
+// and e, the variables x1..xn (x is an alias for x1) and the dimension n.
+// sum(...) and prod(...) iterate over all dimensions; inside them xi is the
+// current coordinate and i its 1-based index, so the Rastrigin function is
+//
+//	10*n + sum(xi^2 - 10*cos(2*pi*xi))
+package expr
+
+import (
+	"fmt"
+	"math"
+)
+
+// SyntaxError reports a malformed expression.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("expr: %s at position %d", e.Msg, e.Pos+1)
+}
+
+// DomainError reports an operation applied outside of its domain, such as
+// log(0) or division by zero.
+type DomainError struct {
+	Op  string
+	Arg float64
+	Pos int
+}
+
+func (e *DomainError) Error() string {
+	if e.Op == "/" {
+		return fmt.Sprintf("expr: division by zero at position %d", e.Pos+1)
+	}
+	return fmt.Sprintf("expr: %s is undefined for %g at position %d", e.Op, e.Arg, e.Pos+1)
+}
+
+type env struct {
+	x   []float64
+	i   int
+	err *DomainError
+}
+
+func (en *env) fail(op string, arg float64, pos int) float64 {
+	if en.err == nil {
+		en.err = &DomainError{Op: op, Arg: arg, Pos: pos}
+	}
+	return math.NaN()
+}
+
+type compiledNode struct {
+	eval  func(*env) float64
+	konst bool
+}
+
+type node = *compiledNode
+
+type function struct {
+	f      func(float64) float64
+	domain func(float64) bool
+}
+
+func positive(v float64) bool    { return v > 0 }
+func nonNegative(v float64) bool { return v >= 0 }
+
+var functions = map[string]function{
+	"sin":  {f: math.Sin},
+	"cos":  {f: math.Cos},
+	"tan":  {f: math.Tan},
+	"exp":  {f: math.Exp},
+	"abs":  {f: math.Abs},
+	"log":  {f: math.Log, domain: positive},
+	"ln":   {f: math.Log, domain: positive},
+	"sqrt": {f: math.Sqrt, domain: nonNegative},
+}
+
+// fold evaluates a node without variables once at compile time.
+func fold(n node) (node, error) {
+	var en env
+	v := n.eval(&en)
+	if en.err != nil {
+		return nil, en.err
+	}
+	return constant(v), nil
+}
+
+func constant(v float64) node {
+	return &compiledNode{eval: func(*env) float64 { return v }, konst: true}
+}
+
+func variable(k int) node {
+	return &compiledNode{eval: func(en *env) float64 { return en.x[k] }}
+}
+
+func dimension() node {
+	return &compiledNode{eval: func(en *env) float64 { return float64(len(en.x)) }}
+}
+
+func currentVariable() node {
+	return &compiledNode{eval: func(en *env) float64 { return en.x[en.i] }}
+}
+
+func currentIndex() node {
+	return &compiledNode{eval: func(en *env) float64 { return float64(en.i + 1) }}
+}
+
+func unary(op token, name string, a node) (node, error) {
+	var n node
+	if name == "neg" {
+		n = &compiledNode{eval: func(en *env) float64 { return -a.eval(en) }}
+	} else {
+		fn := functions[name]
+		pos := op.pos
+		if fn.domain == nil {
+			n = &compiledNode{eval: func(en *env) float64 { return fn.f(a.eval(en)) }}
+		} else {
+			n = &compiledNode{eval: func(en *env) float64 {
+				v := a.eval(en)
+				if !fn.domain(v) {
+					return en.fail(name, v, pos)
+				}
+				return fn.f(v)
+			}}
+		}
+	}
+	if a.konst {
+		return fold(n)
+	}
+	return n, nil
+}
+
+func binary(op token, a, b node) (node, error) {
+	pos := op.pos
+	var eval func(*env) float64
+	switch op.text {
+	case "+":
+		eval = func(en *env) float64 { return a.eval(en) + b.eval(en) }
+	case "-":
+		eval = func(en *env) float64 { return a.eval(en) - b.eval(en) }
+	case "*":
+		eval = func(en *env) float64 { return a.eval(en) * b.eval(en) }
+	case "/":
+		eval = func(en *env) float64 {
+			num, den := a.eval(en), b.eval(en)
+			if den == 0 {
+				return en.fail("/", num, pos)
+			}
+			return num / den
+		}
+	case "^":
+		eval = func(en *env) float64 {
+			base, exp := a.eval(en), b.eval(en)
+			if (base < 0 && exp != math.Trunc(exp)) || (base == 0 && exp < 0) {
+				return en.fail("^", base, pos)
+			}
+			return math.Pow(base, exp)
+		}
+	}
+	n := &compiledNode{eval: eval}
+	if a.konst && b.konst {
+		return fold(n)
+	}
+	return n, nil
+}
+
+func aggregate(name string, a node) node {
+	if name == "prod" {
+		return &compiledNode{eval: func(en *env) float64 {
+			saved, acc := en.i, 1.0
+			for en.i = 0; en.i < len(en.x); en.i++ {
+				acc *= a.eval(en)
+			}
+			en.i = saved
+			return acc
+		}}
+	}
+	return &compiledNode{eval: func(en *env) float64 {
+		saved, acc := en.i, 0.0
+		for en.i = 0; en.i < len(en.x); en.i++ {
+			acc += a.eval(en)
+		}
+		en.i = saved
+		return acc
+	}}
+}
+
+// Expr is a compiled expression. It is safe for concurrent use.
+type Expr struct {
+	src  string
+	root node
+	dim  int
+}
+
+// Compile parses src and compiles it into an evaluator. Subexpressions
+// without variables are evaluated once here, so a domain error in them is
+// reported by Compile.
+func Compile(src string) (*Expr, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %s", t)}
+	}
+	return &Expr{src: src, root: root, dim: p.dim}, nil
+}
+
+// MustCompile is like Compile but panics on error.
+func MustCompile(src string) *Expr {
+	x, err := Compile(src)
+	if err != nil {
+		panic(err)
+	}
+	return x
+}
+
+func (x *Expr) String() string { return x.src }
+
+// Dim returns the highest variable index used explicitly, or 0 if the
+// expression only refers to coordinates through sum and prod.
+func (x *Expr) Dim() int { return x.dim }
+
+// Eval evaluates the expression at v.
+func (x *Expr) Eval(v []float64) (float64, error) {
+	if len(v) < x.dim {
+		return math.NaN(), fmt.Errorf("expr: %q uses x%d but only %d values were given", x.src, x.dim, len(v))
+	}
+	en := env{x: v}
+	r := x.root.eval(&en)
+	if en.err != nil {
+		return math.NaN(), en.err
+	}
+	return r, nil
+}
+
+// Func returns the expression as an objective function that yields NaN
+// where the expression is undefined.
+func (x *Expr) Func() func([]float64) float64 {
+	return func(v []float64) float64 {
+		r, _ := x.Eval(v)
+		return r
+	}
+}
+
+// Func1D is like Func for one-dimensional expressions.
+func (x *Expr) Func1D() func(float64) float64 {
+	return func(v float64) float64 {
+		r, _ := x.Eval([]float64{v})
+		return r
+	}
+}
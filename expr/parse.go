@@ -0,0 +1,276 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of input"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(src) {
+		c := src[i]
+		switch {
+		case c >= utf8.RuneSelf:
+			r, _ := utf8.DecodeRuneInString(src[i:])
+			return nil, &SyntaxError{Pos: i, Msg: fmt.Sprintf("unexpected character %q", r)}
+		case unicode.IsSpace(rune(c)):
+			i++
+		case isDigit(c) || c == '.':
+			start := i
+			for i < len(src) && (isDigit(src[i]) || src[i] == '.') {
+				i++
+			}
+			if i < len(src) && (src[i] == 'e' || src[i] == 'E') {
+				j := i + 1
+				if j < len(src) && (src[j] == '+' || src[j] == '-') {
+					j++
+				}
+				if j < len(src) && isDigit(src[j]) {
+					i = j
+					for i < len(src) && isDigit(src[i]) {
+						i++
+					}
+				}
+			}
+			num, err := strconv.ParseFloat(src[start:i], 64)
+			if err != nil {
+				return nil, &SyntaxError{Pos: start, Msg: fmt.Sprintf("invalid number %q", src[start:i])}
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: src[start:i], num: num, pos: start})
+		case isLetter(c):
+			start := i
+			for i < len(src) && (isLetter(src[i]) || isDigit(src[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: src[start:i], pos: start})
+		case strings.IndexByte("+-*/^(),", c) >= 0:
+			tokens = append(tokens, token{kind: tokOp, text: src[i : i+1], pos: i})
+			i++
+		default:
+			return nil, &SyntaxError{Pos: i, Msg: fmt.Sprintf("unexpected character %q", rune(c))}
+		}
+	}
+	tokens = append(tokens, token{kind: tokEOF, pos: len(src)})
+	return tokens, nil
+}
+
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+func isLetter(c byte) bool { return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+
+// parser is a recursive descent parser for
+//
+//	expr    = term {("+" | "-") term}
+//	term    = unary {("*" | "/") unary}
+//	unary   = "-" unary | "+" unary | power
+//	power   = primary ["^" unary]
+//	primary = number | ident | ident "(" expr ")" | "(" expr ")"
+type parser struct {
+	tokens   []token
+	pos      int
+	aggDepth int
+	dim      int
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOp(op string) bool {
+	t := p.peek()
+	return t.kind == tokOp && t.text == op
+}
+
+func (p *parser) expect(op string) error {
+	t := p.next()
+	if t.kind != tokOp || t.text != op {
+		return &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("expected %q, found %s", op, t)}
+	}
+	return nil
+}
+
+func (p *parser) parseExpr() (node, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("+") || p.isOp("-") {
+		op := p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		if left, err = binary(op, left, right); err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parseTerm() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("*") || p.isOp("/") {
+		op := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if left, err = binary(op, left, right); err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOp("-") {
+		op := p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unary(op, "neg", operand)
+	}
+	if p.isOp("+") {
+		p.next()
+		return p.parseUnary()
+	}
+	return p.parsePower()
+}
+
+func (p *parser) parsePower() (node, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if p.isOp("^") {
+		op := p.next()
+		exp, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return binary(op, base, exp)
+	}
+	return base, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokNumber:
+		return constant(t.num), nil
+	case t.kind == tokOp && t.text == "(":
+		n, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		return n, p.expect(")")
+	case t.kind == tokIdent:
+		if p.isOp("(") {
+			return p.parseCall(t)
+		}
+		return p.parseIdent(t)
+	}
+	return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %s", t)}
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	_, isFunc := functions[name.text]
+	isAgg := name.text == "sum" || name.text == "prod"
+	if !isFunc && !isAgg {
+		return nil, &SyntaxError{Pos: name.pos, Msg: fmt.Sprintf("unknown function %q", name.text)}
+	}
+
+	p.next()
+	if isAgg {
+		p.aggDepth++
+	}
+	arg, err := p.parseExpr()
+	if isAgg {
+		p.aggDepth--
+	}
+	if err != nil {
+		return nil, err
+	}
+	if p.isOp(",") {
+		t := p.peek()
+		return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("%s takes exactly one argument", name.text)}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	if isAgg {
+		return aggregate(name.text, arg), nil
+	}
+	return unary(name, name.text, arg)
+}
+
+func (p *parser) parseIdent(t token) (node, error) {
+	switch t.text {
+	case "pi":
+		return constant(math.Pi), nil
+	case "e":
+		return constant(math.E), nil
+	case "n":
+		return dimension(), nil
+	case "x":
+		p.dim = max(p.dim, 1)
+		return variable(0), nil
+	case "xi", "i":
+		if p.aggDepth == 0 {
+			return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("%q is only defined inside sum() or prod()", t.text)}
+		}
+		if t.text == "xi" {
+			return currentVariable(), nil
+		}
+		return currentIndex(), nil
+	}
+
+	if t.text[0] == 'x' {
+		if k, err := strconv.Atoi(t.text[1:]); err == nil {
+			if k < 1 {
+				return nil, &SyntaxError{Pos: t.pos, Msg: "variables are numbered from x1"}
+			}
+			p.dim = max(p.dim, k)
+			return variable(k - 1), nil
+		}
+	}
+	return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unknown identifier %q", t.text)}
+}
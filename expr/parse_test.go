@@ -0,0 +1,130 @@
+package expr
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCompileRejectsNonASCII(t *testing.T) {
+	for _, src := range []string{"sin(х)", "é + 1", "x1 · 2", "2²"} {
+		_, err := Compile(src)
+		var syntax *SyntaxError
+		if !errors.As(err, &syntax) {
+			t.Errorf("Compile(%q) = %v, want a SyntaxError", src, err)
+		}
+	}
+}
+
+func TestCompileASCII(t *testing.T) {
+	x, err := Compile("x1^2 + 2*x2 - 1.5e1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := x.Eval([]float64{3, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != 2 {
+		t.Errorf("Eval = %g, want 2", v)
+	}
+}
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		src  string
+		x    []float64
+		want float64
+	}{
+		{"-x^2", []float64{3}, -9},
+		{"-2^2", nil, -4},
+		{"(-2)^2", nil, 4},
+		{"2^3^2", nil, 512},
+		{"2^-1", nil, 0.5},
+		{"2*3^2", nil, 18},
+		{"1-2-3", nil, -4},
+		{"8/2/2", nil, 2},
+		{"-x1*-x2", []float64{2, 5}, 10},
+		{"2 + +3", nil, 5},
+		{"n", []float64{1, 2, 3}, 3},
+		{"sum(xi)", []float64{1, 2, 3}, 6},
+		{"sum(i)", []float64{7, 7, 7, 7}, 10},
+		{"prod(xi + 1)", []float64{1, 2, 3}, 24},
+		{"sum(sum(1))", []float64{0, 0, 0}, 9},
+		{"sum(i*prod(2))", []float64{0, 0, 0}, 48},
+		{"sum(xi * sum(xi))", []float64{1, 2}, 9},
+		{"x1 + sum(xi^2) - x2", []float64{1, 2}, 4},
+		{"10*n + sum(xi^2 - 10*cos(2*pi*xi))", []float64{0, 0}, 0},
+	}
+	for _, tt := range tests {
+		x, err := Compile(tt.src)
+		if err != nil {
+			t.Errorf("Compile(%q): %v", tt.src, err)
+			continue
+		}
+		if got, err := x.Eval(tt.x); err != nil || got != tt.want {
+			t.Errorf("%q at %v = %g, %v, want %g", tt.src, tt.x, got, err, tt.want)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want error
+	}{
+		{"xi + 1", &SyntaxError{Pos: 0}},
+		{"1 + i", &SyntaxError{Pos: 4}},
+		{"sum(xi) + xi", &SyntaxError{Pos: 10}},
+		{"sum(1, 2)", &SyntaxError{Pos: 5}},
+		{"x0", &SyntaxError{Pos: 0}},
+		{"foo(1)", &SyntaxError{Pos: 0}},
+		{"(1 + 2", &SyntaxError{Pos: 6}},
+		{"log(0)", &DomainError{Op: "log", Arg: 0, Pos: 0}},
+		{"x + sqrt(-1)", &DomainError{Op: "sqrt", Arg: -1, Pos: 4}},
+		{"1/(2-2)", &DomainError{Op: "/", Arg: 1, Pos: 1}},
+		{"(-8)^(1/3)", &DomainError{Op: "^", Arg: -8, Pos: 4}},
+		{"x1 * 0^-1", &DomainError{Op: "^", Arg: 0, Pos: 6}},
+	}
+	for _, tt := range tests {
+		_, err := Compile(tt.src)
+		if !sameError(err, tt.want) {
+			t.Errorf("Compile(%q) = %v, want %v", tt.src, err, tt.want)
+		}
+	}
+}
+
+func TestEvalDomainErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		x    []float64
+		want *DomainError
+	}{
+		{"log(x)", []float64{0}, &DomainError{Op: "log", Arg: 0, Pos: 0}},
+		{"1 + x1/x2", []float64{1, 0}, &DomainError{Op: "/", Arg: 1, Pos: 6}},
+		{"sum(sqrt(xi))", []float64{1, -4}, &DomainError{Op: "sqrt", Arg: -4, Pos: 4}},
+		{"ln(x1) + ln(x2)", []float64{-1, -2}, &DomainError{Op: "ln", Arg: -1, Pos: 0}},
+		{"x1 ^ x2", []float64{-2, 0.5}, &DomainError{Op: "^", Arg: -2, Pos: 3}},
+	}
+	for _, tt := range tests {
+		x := MustCompile(tt.src)
+		v, err := x.Eval(tt.x)
+		if !math.IsNaN(v) || !sameError(err, tt.want) {
+			t.Errorf("%q at %v = %g, %v, want %v", tt.src, tt.x, v, err, tt.want)
+		}
+	}
+}
+
+// sameError reports whether err has the type and position of want, and
+// for a DomainError also its operation and argument.
+func sameError(err, want error) bool {
+	switch want := want.(type) {
+	case *SyntaxError:
+		var got *SyntaxError
+		return errors.As(err, &got) && got.Pos == want.Pos
+	case *DomainError:
+		var got *DomainError
+		return errors.As(err, &got) && *got == *want
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+// Package fss implements the fish school search from Homework2.
+package fss
+
+import (
+	"context"
+	"math"
+	"math/rand"
+)
+
+type Config struct {
+	Dim        int     // Размерность задачи
+	NumFish    int     // Размер популяции
+	Iterations int     // Количество итераций
+	StepInd    float64 // Индивидуальный шаг
+	StepVol    float64 // Волитивное движение
+	BoundMin   float64 // Минимум области поиска
+	BoundMax   float64 // Максимум области поиска
+	// MaxEvaluations, if positive, stops the search before an iteration
+	// once f has been evaluated that many times.
+	MaxEvaluations int
+	// Seed initializes the random source of the search.
+	Seed int64
+
+	// OnIteration, if set, is called after every iteration with the best
+	// value found so far.
+	OnIteration func(iteration int, bestFitness float64)
+}
+
+type Fish struct {
+	Position      []float64
+	Fitness       float64
+	Mass          float64
+	DeltaPosition []float64
+}
+
+// DefaultConfig returns the parameters used in Homework2.
+func DefaultConfig() Config {
+	return Config{
+		Dim:        2,
+		NumFish:    50,
+		Iterations: 100,
+		StepInd:    0.1,
+		StepVol:    0.01,
+		BoundMin:   -5.12,
+		BoundMax:   5.12,
+	}
+}
+
+// RandomVector returns n values drawn uniformly from [min, max) by r.
+func RandomVector(r *rand.Rand, n int, min, max float64) []float64 {
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = min + r.Float64()*(max-min)
+	}
+	return v
+}
+
+func ClampVector(v []float64, min, max float64) {
+	for i := range v {
+		if v[i] < min {
+			v[i] = min
+		}
+		if v[i] > max {
+			v[i] = max
+		}
+	}
+}
+
+// FishSchoolSearch minimizes f and returns the best position and its value.
+// If ctx is cancelled it returns the best position found so far and
+// ctx.Err().
+func FishSchoolSearch(ctx context.Context, objective func([]float64) float64, cfg Config) ([]float64, float64, error) {
+	evaluations := 0
+	f := func(x []float64) float64 {
+		evaluations++
+		return objective(x)
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	school := make([]Fish, cfg.NumFish)
+	var bestPosition []float64
+	bestFitness := math.MaxFloat64
+
+	for i := range school {
+		pos := RandomVector(rng, cfg.Dim, cfg.BoundMin, cfg.BoundMax)
+		fit := f(pos)
+		school[i] = Fish{
+			Position:      pos,
+			Fitness:       fit,
+			Mass:          1.0,
+			DeltaPosition: make([]float64, cfg.Dim),
+		}
+		if fit < bestFitness {
+			bestFitness = fit
+			bestPosition = append([]float64{}, pos...)
+		}
+	}
+
+	for iter := 0; iter < cfg.Iterations; iter++ {
+		if err := ctx.Err(); err != nil {
+			return bestPosition, bestFitness, err
+		}
+		if cfg.MaxEvaluations > 0 && evaluations >= cfg.MaxEvaluations {
+			break
+		}
+
+		totalWeightGain := 0.0
+
+		for i := range school {
+			direction := RandomVector(rng, cfg.Dim, -1, 1)
+			newPos := make([]float64, cfg.Dim)
+			for j := range newPos {
+				newPos[j] = school[i].Position[j] + direction[j]*cfg.StepInd
+			}
+			ClampVector(newPos, cfg.BoundMin, cfg.BoundMax)
+
+			newFit := f(newPos)
+			if newFit < school[i].Fitness {
+				for j := range school[i].Position {
+					school[i].DeltaPosition[j] = newPos[j] - school[i].Position[j]
+					school[i].Position[j] = newPos[j]
+				}
+				weightGain := school[i].Fitness - newFit
+				school[i].Fitness = newFit
+				school[i].Mass += weightGain
+				totalWeightGain += weightGain
+			} else {
+				for j := range school[i].DeltaPosition {
+					school[i].DeltaPosition[j] = 0
+				}
+			}
+		}
+
+		totalMass := 0.0
+		for i := range school {
+			if school[i].Mass < 1.0 {
+				school[i].Mass = 1.0
+			}
+			if school[i].Mass > 5.0 {
+				school[i].Mass = 5.0
+			}
+			totalMass += school[i].Mass
+		}
+
+		collectiveMove := make([]float64, cfg.Dim)
+		for i := range school {
+			for j := range collectiveMove {
+				collectiveMove[j] += school[i].DeltaPosition[j] * school[i].Mass
+			}
+		}
+		for j := range collectiveMove {
+			collectiveMove[j] /= totalMass
+		}
+		for i := range school {
+			for j := range school[i].Position {
+				school[i].Position[j] += collectiveMove[j]
+			}
+			ClampVector(school[i].Position, cfg.BoundMin, cfg.BoundMax)
+			school[i].Fitness = f(school[i].Position)
+		}
+
+		barycenter := make([]float64, cfg.Dim)
+		for i := range school {
+			for j := range barycenter {
+				barycenter[j] += school[i].Position[j] * school[i].Mass
+			}
+		}
+		for j := range barycenter {
+			barycenter[j] /= totalMass
+		}
+		for i := range school {
+			for j := range school[i].Position {
+				diff := school[i].Position[j] - barycenter[j]
+				if totalWeightGain > 0 {
+					school[i].Position[j] -= cfg.StepVol * rng.Float64() * diff
+				} else {
+					school[i].Position[j] += cfg.StepVol * rng.Float64() * diff
+				}
+			}
+			ClampVector(school[i].Position, cfg.BoundMin, cfg.BoundMax)
+			school[i].Fitness = f(school[i].Position)
+		}
+
+		for _, fish := range school {
+			if fish.Fitness < bestFitness {
+				bestFitness = fish.Fitness
+				bestPosition = append([]float64{}, fish.Position...)
+			}
+		}
+
+		if cfg.OnIteration != nil {
+			cfg.OnIteration(iter+1, bestFitness)
+		}
+	}
+
+	return bestPosition, bestFitness, nil
+}
@@ -0,0 +1,66 @@
+package fss
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+// Optimizer adapts FishSchoolSearch to optimize.Optimizer. The dimension
+// and bounds of the problem override the ones in Config.
+type Optimizer struct {
+	Config Config
+}
+
+func (o Optimizer) Optimize(ctx context.Context, p optimize.Problem[[]float64]) (optimize.Result[[]float64], error) {
+	if p.Encoding() != optimize.Real || p.Dim() == 0 {
+		return optimize.Result[[]float64]{}, errors.New("fss: only real problems are supported")
+	}
+
+	cfg := o.Config
+	lower, upper := p.Bounds()
+	for i := range lower {
+		if lower[i] != lower[0] || upper[i] != upper[0] {
+			return optimize.Result[[]float64]{}, errors.New("fss: per-dimension bounds are not supported")
+		}
+	}
+	cfg.Dim = p.Dim()
+	cfg.BoundMin, cfg.BoundMax = lower[0], upper[0]
+
+	iterations := 0
+	onIteration := cfg.OnIteration
+	cfg.OnIteration = func(iteration int, bestFitness float64) {
+		iterations = iteration
+		if onIteration != nil {
+			onIteration(iteration, bestFitness)
+		}
+	}
+
+	sign := 1.0
+	if p.Direction() == optimize.Maximize {
+		sign = -1.0
+	}
+	counter := &optimize.Counter[[]float64]{F: p.Evaluate}
+	f := func(x []float64) float64 {
+		return sign * counter.Evaluate(x)
+	}
+
+	start := time.Now()
+	best, value, err := FishSchoolSearch(ctx, f, cfg)
+	result := optimize.Result[[]float64]{
+		Best:        best,
+		Value:       sign * value,
+		Evaluations: counter.Count,
+		Iterations:  iterations,
+		Reason:      optimize.MaxIterations,
+		Elapsed:     time.Since(start),
+	}
+	if err != nil {
+		result.Reason = optimize.Cancelled
+	} else if iterations < cfg.Iterations {
+		result.Reason = optimize.MaxEvaluations
+	}
+	return result, err
+}
@@ -0,0 +1,159 @@
+package ga
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Crossover produces two children from two parents, drawing random
+// numbers from r. better is the fitter parent, which only Heuristic relies
+// on. Children are new slices clamped to lower and upper; the parents are
+// not modified.
+type Crossover interface {
+	Cross(r *rand.Rand, better, worse, lower, upper []float64) (c1, c2 []float64)
+	String() string
+}
+
+func clamp(v, lower, upper float64) float64 {
+	return math.Min(math.Max(v, lower), upper)
+}
+
+// Arithmetic returns Lambda*p1 + (1-Lambda)*p2 and the mirrored child.
+// Lambda = 0.5 gives the component-wise mean used in Homework1.
+type Arithmetic struct {
+	Lambda float64
+}
+
+func (a Arithmetic) Cross(r *rand.Rand, p1, p2, lower, upper []float64) ([]float64, []float64) {
+	c1 := make([]float64, len(p1))
+	c2 := make([]float64, len(p1))
+	for i := range p1 {
+		c1[i] = clamp(a.Lambda*p1[i]+(1-a.Lambda)*p2[i], lower[i], upper[i])
+		c2[i] = clamp((1-a.Lambda)*p1[i]+a.Lambda*p2[i], lower[i], upper[i])
+	}
+	return c1, c2
+}
+
+func (a Arithmetic) String() string { return fmt.Sprintf("arithmetic:%g", a.Lambda) }
+
+// UniformArithmetic is Arithmetic with a lambda drawn uniformly from [0, 1]
+// for every gene.
+type UniformArithmetic struct{}
+
+func (UniformArithmetic) Cross(r *rand.Rand, p1, p2, lower, upper []float64) ([]float64, []float64) {
+	c1 := make([]float64, len(p1))
+	c2 := make([]float64, len(p1))
+	for i := range p1 {
+		lambda := r.Float64()
+		c1[i] = clamp(lambda*p1[i]+(1-lambda)*p2[i], lower[i], upper[i])
+		c2[i] = clamp((1-lambda)*p1[i]+lambda*p2[i], lower[i], upper[i])
+	}
+	return c1, c2
+}
+
+func (UniformArithmetic) String() string { return "uniform-arithmetic" }
+
+// BLX is blend crossover: every gene is drawn uniformly from the parents'
+// interval extended by Alpha times its length on both sides.
+type BLX struct {
+	Alpha float64
+}
+
+func (b BLX) Cross(r *rand.Rand, p1, p2, lower, upper []float64) ([]float64, []float64) {
+	c1 := make([]float64, len(p1))
+	c2 := make([]float64, len(p1))
+	for i := range p1 {
+		lo, hi := math.Min(p1[i], p2[i]), math.Max(p1[i], p2[i])
+		ext := b.Alpha * (hi - lo)
+		lo, hi = lo-ext, hi+ext
+		c1[i] = clamp(lo+r.Float64()*(hi-lo), lower[i], upper[i])
+		c2[i] = clamp(lo+r.Float64()*(hi-lo), lower[i], upper[i])
+	}
+	return c1, c2
+}
+
+func (b BLX) String() string { return fmt.Sprintf("blx:%g", b.Alpha) }
+
+// SBX is simulated binary crossover with distribution index Eta; larger
+// values keep children closer to their parents.
+type SBX struct {
+	Eta float64
+}
+
+func (s SBX) Cross(r *rand.Rand, p1, p2, lower, upper []float64) ([]float64, []float64) {
+	c1 := make([]float64, len(p1))
+	c2 := make([]float64, len(p1))
+	for i := range p1 {
+		u := r.Float64()
+		var beta float64
+		if u <= 0.5 {
+			beta = math.Pow(2*u, 1/(s.Eta+1))
+		} else {
+			beta = math.Pow(1/(2*(1-u)), 1/(s.Eta+1))
+		}
+		mean, half := (p1[i]+p2[i])/2, (p1[i]-p2[i])/2
+		c1[i] = clamp(mean+beta*half, lower[i], upper[i])
+		c2[i] = clamp(mean-beta*half, lower[i], upper[i])
+	}
+	return c1, c2
+}
+
+func (s SBX) String() string { return fmt.Sprintf("sbx:%g", s.Eta) }
+
+// Heuristic is Wright's heuristic crossover: each child is
+// better + r*(better - worse) for a fresh random r in [0, 1).
+type Heuristic struct{}
+
+func (Heuristic) Cross(r *rand.Rand, better, worse, lower, upper []float64) ([]float64, []float64) {
+	c1 := make([]float64, len(better))
+	c2 := make([]float64, len(better))
+	r1, r2 := r.Float64(), r.Float64()
+	for i := range better {
+		diff := better[i] - worse[i]
+		c1[i] = clamp(better[i]+r1*diff, lower[i], upper[i])
+		c2[i] = clamp(better[i]+r2*diff, lower[i], upper[i])
+	}
+	return c1, c2
+}
+
+func (Heuristic) String() string { return "heuristic" }
+
+// ParseCrossover parses the names printed by the String methods, for
+// example "blx:0.5" or "heuristic". The parameter may be omitted to use
+// its default.
+func ParseCrossover(s string) (Crossover, error) {
+	name, param, hasParam := strings.Cut(s, ":")
+	value := func(def float64) (float64, error) {
+		if !hasParam {
+			return def, nil
+		}
+		return strconv.ParseFloat(param, 64)
+	}
+
+	var v float64
+	var err error
+	switch name {
+	case "arithmetic":
+		if v, err = value(0.5); err == nil && v >= 0 && v <= 1 {
+			return Arithmetic{Lambda: v}, nil
+		}
+	case "uniform-arithmetic":
+		return UniformArithmetic{}, nil
+	case "blx":
+		if v, err = value(0.5); err == nil && v >= 0 {
+			return BLX{Alpha: v}, nil
+		}
+	case "sbx":
+		if v, err = value(2); err == nil && v >= 0 {
+			return SBX{Eta: v}, nil
+		}
+	case "heuristic":
+		return Heuristic{}, nil
+	default:
+		return nil, fmt.Errorf("unknown crossover %q", name)
+	}
+	return nil, fmt.Errorf("invalid parameter in crossover %q", s)
+}
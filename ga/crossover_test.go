@@ -0,0 +1,83 @@
+package ga
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+var crossovers = []Crossover{
+	Arithmetic{Lambda: 0.5}, Arithmetic{Lambda: 0.1}, UniformArithmetic{},
+	BLX{Alpha: 0}, BLX{Alpha: 0.5}, BLX{Alpha: 3},
+	SBX{Eta: 0}, SBX{Eta: 2}, SBX{Eta: 20}, Heuristic{},
+}
+
+// testLower and testUpper mix wide, narrow, asymmetric and degenerate intervals.
+var testLower, testUpper = []float64{-5, 0, -1e-3, 2, -100}, []float64{5, 1, 1e-3, 2, 1e6}
+
+// edgeIndividual returns an individual lying on the bounds in some genes.
+func edgeIndividual(r *rand.Rand, lower, upper []float64) []float64 {
+	genes := GenIndividual(r, lower, upper)
+	for i := range genes {
+		switch r.Intn(4) {
+		case 0:
+			genes[i] = lower[i]
+		case 1:
+			genes[i] = upper[i]
+		}
+	}
+	return genes
+}
+
+func inBounds(genes, lower, upper []float64) bool {
+	for i, x := range genes {
+		if !(x >= lower[i] && x <= upper[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCrossoverBoundsAndParents(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, crossover := range crossovers {
+		for trial := 0; trial < 2000; trial++ {
+			p1 := GenIndividual(r, testLower, testUpper)
+			p2 := edgeIndividual(r, testLower, testUpper)
+			if trial%2 == 0 {
+				p1, p2 = p2, p1
+			}
+			want1, want2 := slices.Clone(p1), slices.Clone(p2)
+
+			c1, c2 := crossover.Cross(r, p1, p2, testLower, testUpper)
+			if len(c1) != len(p1) || len(c2) != len(p1) {
+				t.Fatalf("%s: children of length %d and %d, want %d", crossover, len(c1), len(c2), len(p1))
+			}
+			if !inBounds(c1, testLower, testUpper) || !inBounds(c2, testLower, testUpper) {
+				t.Fatalf("%s: children %v and %v leave the bounds", crossover, c1, c2)
+			}
+			// The children must not share storage with the parents either.
+			for i := range c1 {
+				c1[i]++
+				c2[i]++
+			}
+			if !slices.Equal(p1, want1) || !slices.Equal(p2, want2) {
+				t.Fatalf("%s modified the parents", crossover)
+			}
+		}
+	}
+}
+
+func TestParseCrossover(t *testing.T) {
+	for _, crossover := range crossovers {
+		got, err := ParseCrossover(crossover.String())
+		if err != nil || got != crossover {
+			t.Errorf("ParseCrossover(%q) = %v, %v", crossover.String(), got, err)
+		}
+	}
+	for _, s := range []string{"arithmetic:2", "blx:-1", "sbx:x", "two-point"} {
+		if _, err := ParseCrossover(s); err == nil {
+			t.Errorf("ParseCrossover(%q) succeeded", s)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+// Package ga implements the real-coded genetic algorithm from Homework1,
+// generalized to box-bounded problems of any dimension.
+package ga
+
+import (
+	"context"
+	"math"
+	"math/rand"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+type Config struct {
+	PopulationSize int
+	// Selection chooses parents; nil means a tournament of three.
+	Selection Selection
+	// Crossover recombines parents; nil means Arithmetic{Lambda: 0.5}.
+	Crossover     Crossover
+	CrossoverRate float64
+	// Mutation perturbs children; nil means UniformMutation{Width: 0.05}.
+	// MutationRate is its per-gene probability.
+	Mutation     Mutation
+	MutationRate float64
+	// Replacement forms the next generation; nil means Generational.
+	Replacement Replacement
+	// Lower and Upper are the per-dimension bounds; their length is the
+	// dimension of the problem.
+	Lower           []float64
+	Upper           []float64
+	StagnationLimit int
+	// MaxGenerations and MaxEvaluations stop the run early when positive.
+	MaxGenerations int
+	MaxEvaluations int
+	Direction      optimize.Direction
+	// Migration splits the population into islands when enabled. The
+	// islands evolve concurrently, so the fitness function must then be
+	// safe to call from several goroutines at once.
+	Migration Migration
+	// Seed initializes the random source of the run, so runs with the
+	// same Config and objective are identical.
+	Seed int64
+
+	// OnGeneration, if set, is called after every generation with the best
+	// individual found so far.
+	OnGeneration func(generation int, best []float64, value float64)
+}
+
+type Individual struct {
+	Genes   []float64
+	Fitness float64
+	// Sigmas are the step sizes of SelfAdaptiveMutation, nil otherwise.
+	Sigmas []float64
+}
+
+type Result struct {
+	Best        []float64
+	Value       float64
+	Generations int
+	Evaluations int
+	Reason      optimize.TerminationReason
+}
+
+// DefaultConfig returns the parameters used in the Homework1 report.
+func DefaultConfig() Config {
+	return Config{
+		PopulationSize:  70,
+		Selection:       Tournament{Size: 3},
+		Crossover:       Arithmetic{Lambda: 0.5},
+		CrossoverRate:   0.7,
+		Mutation:        UniformMutation{Width: 0.05},
+		MutationRate:    0.1,
+		Replacement:     Generational{},
+		Lower:           []float64{2.0},
+		Upper:           []float64{4.0},
+		StagnationLimit: 20,
+		Direction:       optimize.Maximize,
+	}
+}
+
+// Bounds returns dim-dimensional bounds with the same interval in every
+// dimension.
+func Bounds(dim int, min, max float64) (lower, upper []float64) {
+	lower = make([]float64, dim)
+	upper = make([]float64, dim)
+	for i := 0; i < dim; i++ {
+		lower[i] = min
+		upper[i] = max
+	}
+	return
+}
+
+func GenIndividual(r *rand.Rand, lower, upper []float64) []float64 {
+	genes := make([]float64, len(lower))
+	for i := range genes {
+		genes[i] = lower[i] + (upper[i]-lower[i])*r.Float64()
+	}
+	return genes
+}
+
+func GenPopulation(r *rand.Rand, size int, lower, upper []float64) (res [][]float64) {
+	res = make([][]float64, size)
+
+	for i := range res {
+		res[i] = GenIndividual(r, lower, upper)
+	}
+	return
+}
+
+// Score converts a fitness value into a Selection score, where larger is
+// better and undefined values are -Inf.
+func Score(fitness float64, dir optimize.Direction) float64 {
+	if math.IsNaN(fitness) {
+		return math.Inf(-1)
+	}
+	if dir == optimize.Minimize {
+		return -fitness
+	}
+	return fitness
+}
+
+// Crossingover applies crossover with probability crossProb and otherwise
+// returns copies of the parents, drawing random numbers from r. p1 must be
+// the fitter parent. Step sizes of self-adaptive parents are averaged into
+// both children.
+func Crossingover(r *rand.Rand, crossover Crossover, p1, p2 Individual, crossProb float64, lower, upper []float64) (Individual, Individual) {
+	var c1, c2 Individual
+	if r.Float64() < crossProb {
+		c1.Genes, c2.Genes = crossover.Cross(r, p1.Genes, p2.Genes, lower, upper)
+		if p1.Sigmas != nil && p2.Sigmas != nil {
+			c1.Sigmas = make([]float64, len(p1.Sigmas))
+			for i := range c1.Sigmas {
+				c1.Sigmas[i] = (p1.Sigmas[i] + p2.Sigmas[i]) / 2
+			}
+			c2.Sigmas = append([]float64(nil), c1.Sigmas...)
+		}
+		return c1, c2
+	}
+	c1.Genes = append([]float64(nil), p1.Genes...)
+	c2.Genes = append([]float64(nil), p2.Genes...)
+	if p1.Sigmas != nil {
+		c1.Sigmas = append([]float64(nil), p1.Sigmas...)
+	}
+	if p2.Sigmas != nil {
+		c2.Sigmas = append([]float64(nil), p2.Sigmas...)
+	}
+	return c1, c2
+}
+
+// Run optimizes fitness within cfg.Lower and cfg.Upper in cfg.Direction.
+// It stops after cfg.StagnationLimit generations without improvement or
+// when one of the optional generation and evaluation limits is reached. If
+// ctx is cancelled it returns the best individual found so far and
+// ctx.Err(). With cfg.Migration enabled the population is split into
+// islands that call fitness concurrently, see RunIslands.
+func Run(ctx context.Context, fitness func([]float64) float64, cfg Config) (Result, error) {
+	if cfg.Migration.Enabled() {
+		return RunIslands(ctx, fitness, cfg)
+	}
+	r := newRun(ctx, rand.New(rand.NewSource(cfg.Seed)), fitness, cfg)
+	for !r.step() {
+		if cfg.OnGeneration != nil {
+			cfg.OnGeneration(r.result.Generations-1, r.best.Genes, r.best.Fitness)
+		}
+	}
+	return r.finish()
+}
+
+// run is the state of a single population between generations.
+type run struct {
+	ctx         context.Context
+	rng         *rand.Rand
+	fitness     func([]float64) float64
+	cfg         Config
+	selection   Selection
+	crossover   Crossover
+	mutation    Mutation
+	replacement Replacement
+
+	population      []Individual
+	best            Individual
+	stagnationCount int
+	result          Result
+
+	lambda          int
+	scores          []float64
+	offspringScores []float64
+}
+
+func newRun(ctx context.Context, rng *rand.Rand, fitness func([]float64) float64, cfg Config) *run {
+	r := &run{
+		ctx:         ctx,
+		rng:         rng,
+		fitness:     fitness,
+		cfg:         cfg,
+		selection:   cfg.Selection,
+		crossover:   cfg.Crossover,
+		mutation:    cfg.Mutation,
+		replacement: cfg.Replacement,
+		result:      Result{Reason: optimize.NoImprovement},
+	}
+	if r.selection == nil {
+		r.selection = Tournament{Size: 3}
+	}
+	if r.crossover == nil {
+		r.crossover = Arithmetic{Lambda: 0.5}
+	}
+	if r.mutation == nil {
+		r.mutation = UniformMutation{Width: 0.05}
+	}
+	if r.replacement == nil {
+		r.replacement = Generational{}
+	}
+
+	r.population = make([]Individual, cfg.PopulationSize)
+	for i, genes := range GenPopulation(rng, cfg.PopulationSize, cfg.Lower, cfg.Upper) {
+		r.population[i].Genes = genes
+		r.evaluate(&r.population[i])
+	}
+	r.best = r.population[0]
+	for _, ind := range r.population {
+		if cfg.Direction.Better(ind.Fitness, r.best.Fitness) {
+			r.best = ind
+		}
+	}
+	r.lambda = r.replacement.Offspring(cfg.PopulationSize)
+	r.scores = make([]float64, cfg.PopulationSize)
+	r.offspringScores = make([]float64, r.lambda)
+	return r
+}
+
+func (r *run) evaluate(ind *Individual) {
+	r.result.Evaluations++
+	ind.Fitness = r.fitness(ind.Genes)
+}
+
+// step breeds one generation, or sets the termination reason and returns
+// true if the run has to stop first.
+func (r *run) step() bool {
+	cfg := r.cfg
+	switch {
+	case r.stagnationCount >= cfg.StagnationLimit:
+		return true
+	case r.ctx.Err() != nil:
+		r.result.Reason = optimize.Cancelled
+		return true
+	case cfg.MaxGenerations > 0 && r.result.Generations >= cfg.MaxGenerations:
+		r.result.Reason = optimize.MaxIterations
+		return true
+	case cfg.MaxEvaluations > 0 && r.result.Evaluations >= cfg.MaxEvaluations:
+		r.result.Reason = optimize.MaxEvaluations
+		return true
+	}
+
+	for i, ind := range r.population {
+		r.scores[i] = Score(ind.Fitness, cfg.Direction)
+	}
+	parents := r.selection.Select(r.rng, r.scores, (r.lambda+1)/2*2)
+	offspring := make([]Individual, 0, r.lambda)
+	mc := MutationContext{
+		Rate:           cfg.MutationRate,
+		Lower:          cfg.Lower,
+		Upper:          cfg.Upper,
+		Generation:     r.result.Generations,
+		MaxGenerations: cfg.MaxGenerations,
+	}
+	successes := 0
+
+	for len(offspring) < r.lambda {
+		p1 := r.population[parents[len(offspring)]]
+		p2 := r.population[parents[len(offspring)+1]]
+		if cfg.Direction.Better(p2.Fitness, p1.Fitness) {
+			p1, p2 = p2, p1
+		}
+		child1, child2 := Crossingover(r.rng, r.crossover, p1, p2, cfg.CrossoverRate, cfg.Lower, cfg.Upper)
+		for _, child := range []Individual{child1, child2} {
+			if len(offspring) == r.lambda {
+				break
+			}
+			r.mutation.Mutate(r.rng, &child, mc)
+			r.evaluate(&child)
+			if cfg.Direction.Better(child.Fitness, p1.Fitness) {
+				successes++
+			}
+			offspring = append(offspring, child)
+		}
+	}
+	if adaptive, ok := r.mutation.(Adaptive); ok && r.lambda > 0 {
+		r.mutation = adaptive.Adapt(float64(successes) / float64(r.lambda))
+	}
+
+	improved := false
+	for i, ind := range offspring {
+		r.offspringScores[i] = Score(ind.Fitness, cfg.Direction)
+		if cfg.Direction.Better(ind.Fitness, r.best.Fitness) {
+			r.best = ind
+			improved = true
+		}
+	}
+
+	newPopulation := make([]Individual, cfg.PopulationSize)
+	for i, idx := range r.replacement.Survivors(r.rng, r.scores, r.offspringScores) {
+		if idx < cfg.PopulationSize {
+			newPopulation[i] = r.population[idx]
+		} else {
+			newPopulation[i] = offspring[idx-cfg.PopulationSize]
+		}
+	}
+	r.population = newPopulation
+
+	if improved {
+		r.stagnationCount = 0
+	} else {
+		r.stagnationCount++
+	}
+	r.result.Generations++
+	return false
+}
+
+// finish returns the result of a stopped run.
+func (r *run) finish() (Result, error) {
+	r.result.Best, r.result.Value = r.best.Genes, r.best.Fitness
+	if r.result.Reason == optimize.Cancelled {
+		return r.result, r.ctx.Err()
+	}
+	return r.result, nil
+}
@@ -0,0 +1,306 @@
+package ga
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+// Topology decides which islands receive the migrants of an island.
+type Topology interface {
+	// Destinations returns the islands that receive the migrants of
+	// island i out of n, drawing random numbers from r.
+	Destinations(r *rand.Rand, i, n int) []int
+	String() string
+}
+
+// Ring sends the migrants of every island to the next one.
+type Ring struct{}
+
+func (Ring) Destinations(r *rand.Rand, i, n int) []int { return []int{(i + 1) % n} }
+
+func (Ring) String() string { return "ring" }
+
+// FullyConnected sends the migrants of every island to all the others.
+type FullyConnected struct{}
+
+func (FullyConnected) Destinations(r *rand.Rand, i, n int) []int {
+	destinations := make([]int, 0, n-1)
+	for j := 0; j < n; j++ {
+		if j != i {
+			destinations = append(destinations, j)
+		}
+	}
+	return destinations
+}
+
+func (FullyConnected) String() string { return "full" }
+
+// RandomTopology sends the migrants of every island to a different
+// random island at every migration.
+type RandomTopology struct{}
+
+func (RandomTopology) Destinations(r *rand.Rand, i, n int) []int {
+	j := r.Intn(n - 1)
+	if j >= i {
+		j++
+	}
+	return []int{j}
+}
+
+func (RandomTopology) String() string { return "random" }
+
+// Migration configures the island model: the population is split into
+// Islands subpopulations that evolve concurrently and, every Interval
+// generations, send copies of their Migrants best individuals to the
+// islands chosen by Topology, where they replace the worst ones.
+type Migration struct {
+	// Islands is the number of subpopulations; below two the population
+	// is panmictic.
+	Islands int
+	// Interval is the number of generations between migrations; below one
+	// means every generation.
+	Interval int
+	Migrants int
+	// Topology chooses the destinations of migrants; nil means Ring.
+	Topology Topology
+}
+
+// Enabled reports whether m splits the population into islands.
+func (m Migration) Enabled() bool { return m.Islands > 1 }
+
+func (m Migration) String() string {
+	if !m.Enabled() {
+		return "none"
+	}
+	topology := m.Topology
+	if topology == nil {
+		topology = Ring{}
+	}
+	return fmt.Sprintf("%s:%d/%d/%d", topology, m.Islands, m.Interval, m.Migrants)
+}
+
+// Island is a subpopulation evolved by EvolveIslands.
+type Island[T any] interface {
+	// Evolve runs up to generations generations and reports whether the
+	// island has stopped.
+	Evolve(generations int) (stopped bool)
+	// Population returns the individuals of the island and their
+	// Selection scores.
+	Population() ([]T, []float64)
+	// Replace puts a copy of ind at position i of the population.
+	Replace(i int, ind T)
+}
+
+// EvolveIslands evolves islands concurrently, one goroutine per island,
+// m.Interval generations at a time, and migrates between them after every
+// such epoch until all of them have stopped. Stopped islands keep sending
+// migrants but no longer receive any. Random topologies draw from r.
+// afterEpoch, if not nil, is called after every epoch, once all islands
+// have finished it, and stops the evolution by returning true.
+func EvolveIslands[T any](r *rand.Rand, islands []Island[T], m Migration, afterEpoch func() (stop bool)) {
+	interval := max(m.Interval, 1)
+	stopped := make([]bool, len(islands))
+	for {
+		var wg sync.WaitGroup
+		for i, island := range islands {
+			if stopped[i] {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				stopped[i] = island.Evolve(interval)
+			}()
+		}
+		wg.Wait()
+		if afterEpoch != nil && afterEpoch() {
+			return
+		}
+
+		running := false
+		for _, s := range stopped {
+			running = running || !s
+		}
+		if !running {
+			return
+		}
+		migrate(r, islands, m, stopped)
+	}
+}
+
+// migrate sends the best individuals of every island to its destinations.
+// All emigrants are chosen before any island receives immigrants.
+func migrate[T any](r *rand.Rand, islands []Island[T], m Migration, stopped []bool) {
+	topology := m.Topology
+	if topology == nil {
+		topology = Ring{}
+	}
+
+	incoming := make([][]T, len(islands))
+	for i, island := range islands {
+		population, scores := island.Population()
+		var emigrants []T
+		for _, idx := range bestFirst(scores)[:min(m.Migrants, len(scores))] {
+			emigrants = append(emigrants, population[idx])
+		}
+		for _, d := range topology.Destinations(r, i, len(islands)) {
+			incoming[d] = append(incoming[d], emigrants...)
+		}
+	}
+
+	for d, island := range islands {
+		if stopped[d] {
+			continue
+		}
+		_, scores := island.Population()
+		order := bestFirst(scores)
+		for k, ind := range incoming[d][:min(len(incoming[d]), len(order))] {
+			island.Replace(order[len(order)-1-k], ind)
+		}
+	}
+}
+
+// realIsland adapts a run of the real-coded GA to Island.
+type realIsland struct {
+	*run
+}
+
+func (r realIsland) Evolve(generations int) bool {
+	for k := 0; k < generations; k++ {
+		if r.step() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r realIsland) Population() ([]Individual, []float64) {
+	scores := make([]float64, len(r.population))
+	for i, ind := range r.population {
+		scores[i] = Score(ind.Fitness, r.cfg.Direction)
+	}
+	return r.population, scores
+}
+
+func (r realIsland) Replace(i int, ind Individual) {
+	ind.Genes = append([]float64(nil), ind.Genes...)
+	if ind.Sigmas != nil {
+		ind.Sigmas = append([]float64(nil), ind.Sigmas...)
+	}
+	r.population[i] = ind
+}
+
+// RunIslands is Run with the population and the evaluation budget split
+// evenly among cfg.Migration.Islands islands, each with a random source
+// seeded in turn from cfg.Seed. The islands evolve in their own goroutines
+// and call fitness concurrently. The generation and stagnation limits
+// apply to every island on its own. The result holds the best individual
+// of all islands and the termination reason of the island that found it;
+// OnGeneration is called after every epoch with the largest generation
+// count among the islands.
+func RunIslands(ctx context.Context, fitness func([]float64) float64, cfg Config) (Result, error) {
+	m := cfg.Migration
+	sub := cfg
+	sub.Migration = Migration{}
+	sub.PopulationSize = max(cfg.PopulationSize/m.Islands, 2)
+	if cfg.MaxEvaluations > 0 {
+		sub.MaxEvaluations = max(cfg.MaxEvaluations/m.Islands, 1)
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	runs := make([]*run, m.Islands)
+	islands := make([]Island[Individual], m.Islands)
+	for i := range runs {
+		runs[i] = newRun(ctx, rand.New(rand.NewSource(rng.Int63())), fitness, sub)
+		islands[i] = realIsland{runs[i]}
+	}
+
+	// best returns the run holding the best individual.
+	best := func() *run {
+		b := runs[0]
+		for _, r := range runs[1:] {
+			if cfg.Direction.Better(r.best.Fitness, b.best.Fitness) {
+				b = r
+			}
+		}
+		return b
+	}
+	generations := func() int {
+		g := 0
+		for _, r := range runs {
+			g = max(g, r.result.Generations)
+		}
+		return g
+	}
+
+	var afterEpoch func() bool
+	if cfg.OnGeneration != nil {
+		afterEpoch = func() bool {
+			b := best()
+			cfg.OnGeneration(generations()-1, b.best.Genes, b.best.Fitness)
+			return false
+		}
+	}
+	EvolveIslands(rng, islands, m, afterEpoch)
+
+	b := best()
+	result := Result{
+		Best:        b.best.Genes,
+		Value:       b.best.Fitness,
+		Generations: generations(),
+		Reason:      b.result.Reason,
+	}
+	for _, r := range runs {
+		result.Evaluations += r.result.Evaluations
+	}
+	if err := ctx.Err(); err != nil {
+		result.Reason = optimize.Cancelled
+		return result, err
+	}
+	return result, nil
+}
+
+// ParseMigration parses "TOPOLOGY:ISLANDS/INTERVAL/MIGRANTS", where
+// TOPOLOGY is ring, full or random, for example "ring:4/10/2". "none"
+// disables the island model.
+func ParseMigration(s string) (Migration, error) {
+	if s == "none" || s == "" {
+		return Migration{}, nil
+	}
+	name, param, _ := strings.Cut(s, ":")
+	var m Migration
+	switch name {
+	case "ring":
+		m.Topology = Ring{}
+	case "full":
+		m.Topology = FullyConnected{}
+	case "random":
+		m.Topology = RandomTopology{}
+	default:
+		return Migration{}, fmt.Errorf("unknown topology %q", name)
+	}
+
+	fields := strings.Split(param, "/")
+	if len(fields) != 3 {
+		return Migration{}, fmt.Errorf("invalid parameters in migration %q", s)
+	}
+	values := make([]int, len(fields))
+	for i, f := range fields {
+		v, err := strconv.Atoi(f)
+		if err != nil || v < 1 {
+			return Migration{}, fmt.Errorf("invalid parameters in migration %q", s)
+		}
+		values[i] = v
+	}
+	m.Islands, m.Interval, m.Migrants = values[0], values[1], values[2]
+	if m.Islands < 2 {
+		return Migration{}, fmt.Errorf("migration %q needs at least two islands", s)
+	}
+	return m, nil
+}
@@ -0,0 +1,240 @@
+package ga
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// defaultHorizon is the number of generations NonUniformMutation decays
+// over when Config.MaxGenerations is not set.
+const defaultHorizon = 100
+
+// MutationContext is the state of the run a Mutation may depend on.
+type MutationContext struct {
+	Rate           float64
+	Lower          []float64
+	Upper          []float64
+	Generation     int
+	MaxGenerations int
+}
+
+// Mutation changes the genes of ind in place, keeping them within bounds
+// and drawing random numbers from r.
+type Mutation interface {
+	Mutate(r *rand.Rand, ind *Individual, mc MutationContext)
+	String() string
+}
+
+// Adaptive is implemented by mutations that adjust themselves between
+// generations. successRate is the share of mutated children that were
+// better than their fitter parent; Adapt returns the mutation to use in
+// the next generation.
+type Adaptive interface {
+	Adapt(successRate float64) Mutation
+}
+
+// UniformMutation shifts each gene with probability Rate by a uniform delta
+// within ±Width/2 of its interval. Width = 0.05 is the Homework1 mutation.
+type UniformMutation struct {
+	Width float64
+}
+
+func (u UniformMutation) Mutate(r *rand.Rand, ind *Individual, mc MutationContext) {
+	for i := range ind.Genes {
+		if r.Float64() < mc.Rate {
+			delta := (r.Float64() - 0.5) * u.Width * (mc.Upper[i] - mc.Lower[i])
+			ind.Genes[i] = clamp(ind.Genes[i]+delta, mc.Lower[i], mc.Upper[i])
+		}
+	}
+}
+
+func (u UniformMutation) String() string { return fmt.Sprintf("uniform:%g", u.Width) }
+
+// GaussianMutation adds normal noise with a standard deviation of Sigma
+// times the interval length to each gene with probability Rate.
+type GaussianMutation struct {
+	Sigma float64
+}
+
+func (g GaussianMutation) Mutate(r *rand.Rand, ind *Individual, mc MutationContext) {
+	gaussian(r, ind.Genes, g.Sigma, mc)
+}
+
+func (g GaussianMutation) String() string { return fmt.Sprintf("gaussian:%g", g.Sigma) }
+
+func gaussian(r *rand.Rand, genes []float64, sigma float64, mc MutationContext) {
+	for i := range genes {
+		if r.Float64() < mc.Rate {
+			width := mc.Upper[i] - mc.Lower[i]
+			genes[i] = clamp(genes[i]+r.NormFloat64()*sigma*width, mc.Lower[i], mc.Upper[i])
+		}
+	}
+}
+
+// PolynomialMutation is Deb's bounded polynomial mutation with
+// distribution index Eta; larger values give smaller steps.
+type PolynomialMutation struct {
+	Eta float64
+}
+
+func (p PolynomialMutation) Mutate(r *rand.Rand, ind *Individual, mc MutationContext) {
+	power := 1 / (p.Eta + 1)
+	for i, x := range ind.Genes {
+		if r.Float64() >= mc.Rate {
+			continue
+		}
+		lo, hi := mc.Lower[i], mc.Upper[i]
+		width := hi - lo
+		if width <= 0 {
+			continue
+		}
+		u := r.Float64()
+		var deltaQ float64
+		if u < 0.5 {
+			xy := 1 - (x-lo)/width
+			val := 2*u + (1-2*u)*math.Pow(xy, p.Eta+1)
+			deltaQ = math.Pow(val, power) - 1
+		} else {
+			xy := 1 - (hi-x)/width
+			val := 2*(1-u) + 2*(u-0.5)*math.Pow(xy, p.Eta+1)
+			deltaQ = 1 - math.Pow(val, power)
+		}
+		ind.Genes[i] = clamp(x+deltaQ*width, lo, hi)
+	}
+}
+
+func (p PolynomialMutation) String() string { return fmt.Sprintf("polynomial:%g", p.Eta) }
+
+// NonUniformMutation is Michalewicz's non-uniform mutation: steps shrink
+// towards zero as the run approaches Config.MaxGenerations, faster for
+// larger B.
+type NonUniformMutation struct {
+	B float64
+}
+
+func (n NonUniformMutation) Mutate(r *rand.Rand, ind *Individual, mc MutationContext) {
+	horizon := mc.MaxGenerations
+	if horizon <= 0 {
+		horizon = defaultHorizon
+	}
+	progress := math.Min(1, float64(mc.Generation)/float64(horizon))
+	delta := func(y float64) float64 {
+		return y * (1 - math.Pow(r.Float64(), math.Pow(1-progress, n.B)))
+	}
+	for i, x := range ind.Genes {
+		if r.Float64() >= mc.Rate {
+			continue
+		}
+		if r.Float64() < 0.5 {
+			x += delta(mc.Upper[i] - x)
+		} else {
+			x -= delta(x - mc.Lower[i])
+		}
+		ind.Genes[i] = clamp(x, mc.Lower[i], mc.Upper[i])
+	}
+}
+
+func (n NonUniformMutation) String() string { return fmt.Sprintf("non-uniform:%g", n.B) }
+
+// SelfAdaptiveMutation carries one step size per gene on the individual,
+// as in evolution strategies. Step sizes start at InitialSigma times the
+// interval length and are mutated log-normally before every gene is
+// perturbed; Rate is not used.
+type SelfAdaptiveMutation struct {
+	InitialSigma float64
+}
+
+func (s SelfAdaptiveMutation) Mutate(r *rand.Rand, ind *Individual, mc MutationContext) {
+	n := float64(len(ind.Genes))
+	if ind.Sigmas == nil {
+		ind.Sigmas = make([]float64, len(ind.Genes))
+		for i := range ind.Sigmas {
+			ind.Sigmas[i] = s.InitialSigma * (mc.Upper[i] - mc.Lower[i])
+		}
+	}
+	tauGlobal := 1 / math.Sqrt(2*n)
+	tauLocal := 1 / math.Sqrt(2*math.Sqrt(n))
+	global := tauGlobal * r.NormFloat64()
+	for i := range ind.Genes {
+		minSigma := 1e-12 * (mc.Upper[i] - mc.Lower[i])
+		ind.Sigmas[i] = math.Max(ind.Sigmas[i]*math.Exp(global+tauLocal*r.NormFloat64()), minSigma)
+		ind.Genes[i] = clamp(ind.Genes[i]+ind.Sigmas[i]*r.NormFloat64(), mc.Lower[i], mc.Upper[i])
+	}
+}
+
+func (s SelfAdaptiveMutation) String() string {
+	return fmt.Sprintf("self-adaptive:%g", s.InitialSigma)
+}
+
+// OneFifthRule is GaussianMutation whose Sigma follows Rechenberg's 1/5
+// success rule: with Factor in (0, 1), Sigma is divided by Factor when
+// more than a fifth of the children improve on their parents and
+// multiplied by it when fewer do.
+type OneFifthRule struct {
+	Sigma  float64
+	Factor float64
+}
+
+func (o OneFifthRule) Mutate(r *rand.Rand, ind *Individual, mc MutationContext) {
+	gaussian(r, ind.Genes, o.Sigma, mc)
+}
+
+func (o OneFifthRule) Adapt(successRate float64) Mutation {
+	switch {
+	case successRate > 0.2:
+		o.Sigma /= o.Factor
+	case successRate < 0.2:
+		o.Sigma *= o.Factor
+	}
+	return o
+}
+
+func (o OneFifthRule) String() string { return fmt.Sprintf("one-fifth:%g", o.Sigma) }
+
+// ParseMutation parses the names printed by the String methods, for
+// example "gaussian:0.01" or "polynomial:20". The parameter may be
+// omitted to use its default.
+func ParseMutation(s string) (Mutation, error) {
+	name, param, hasParam := strings.Cut(s, ":")
+	value := func(def float64) (float64, error) {
+		if !hasParam {
+			return def, nil
+		}
+		return strconv.ParseFloat(param, 64)
+	}
+
+	var v float64
+	var err error
+	switch name {
+	case "uniform":
+		if v, err = value(0.05); err == nil && v > 0 {
+			return UniformMutation{Width: v}, nil
+		}
+	case "gaussian":
+		if v, err = value(0.01); err == nil && v > 0 {
+			return GaussianMutation{Sigma: v}, nil
+		}
+	case "polynomial":
+		if v, err = value(20); err == nil && v >= 0 {
+			return PolynomialMutation{Eta: v}, nil
+		}
+	case "non-uniform":
+		if v, err = value(5); err == nil && v > 0 {
+			return NonUniformMutation{B: v}, nil
+		}
+	case "self-adaptive":
+		if v, err = value(0.1); err == nil && v > 0 {
+			return SelfAdaptiveMutation{InitialSigma: v}, nil
+		}
+	case "one-fifth":
+		if v, err = value(0.1); err == nil && v > 0 {
+			return OneFifthRule{Sigma: v, Factor: 0.817}, nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown mutation %q", name)
+	}
+	return nil, fmt.Errorf("invalid parameter in mutation %q", s)
+}
@@ -0,0 +1,94 @@
+package ga
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+var mutations = []Mutation{
+	UniformMutation{Width: 0.05}, UniformMutation{Width: 4},
+	GaussianMutation{Sigma: 0.01}, GaussianMutation{Sigma: 2},
+	PolynomialMutation{Eta: 0}, PolynomialMutation{Eta: 20},
+	NonUniformMutation{B: 0.5}, NonUniformMutation{B: 5},
+	SelfAdaptiveMutation{InitialSigma: 0.1}, OneFifthRule{Sigma: 0.5, Factor: 0.817},
+}
+
+func TestMutationBounds(t *testing.T) {
+	tests := []struct {
+		name string
+		mc   MutationContext
+	}{
+		{"start", MutationContext{Rate: 1, Generation: 0, MaxGenerations: 50}},
+		{"middle", MutationContext{Rate: 0.5, Generation: 25, MaxGenerations: 50}},
+		{"end", MutationContext{Rate: 1, Generation: 50, MaxGenerations: 50}},
+		{"past end", MutationContext{Rate: 1, Generation: 80, MaxGenerations: 50}},
+		{"no horizon", MutationContext{Rate: 1, Generation: 10}},
+	}
+	r := rand.New(rand.NewSource(1))
+	for _, mutation := range mutations {
+		for _, tt := range tests {
+			mc := tt.mc
+			mc.Lower, mc.Upper = testLower, testUpper
+			for trial := 0; trial < 500; trial++ {
+				ind := Individual{Genes: edgeIndividual(r, testLower, testUpper)}
+				for i := 0; i < 5; i++ {
+					mutation.Mutate(r, &ind, mc)
+					if !inBounds(ind.Genes, testLower, testUpper) {
+						t.Fatalf("%s at %s: genes %v leave the bounds", mutation, tt.name, ind.Genes)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestMutationRateZero(t *testing.T) {
+	mc := MutationContext{Lower: testLower, Upper: testUpper, MaxGenerations: 50}
+	r := rand.New(rand.NewSource(1))
+	for _, mutation := range mutations {
+		if _, ok := mutation.(SelfAdaptiveMutation); ok {
+			continue // does not use Rate
+		}
+		ind := Individual{Genes: GenIndividual(r, testLower, testUpper)}
+		want := slices.Clone(ind.Genes)
+		mutation.Mutate(r, &ind, mc)
+		if !slices.Equal(ind.Genes, want) {
+			t.Errorf("%s with rate 0 changed %v to %v", mutation, want, ind.Genes)
+		}
+	}
+}
+
+func TestOneFifthRule(t *testing.T) {
+	o := OneFifthRule{Sigma: 1, Factor: 0.5}
+	tests := []struct {
+		successRate float64
+		want        float64
+	}{
+		{0.5, 2},
+		{0.2, 1},
+		{0.1, 0.5},
+	}
+	for _, tt := range tests {
+		if got := o.Adapt(tt.successRate).(OneFifthRule).Sigma; got != tt.want {
+			t.Errorf("Adapt(%g).Sigma = %g, want %g", tt.successRate, got, tt.want)
+		}
+	}
+}
+
+func TestParseMutation(t *testing.T) {
+	for _, mutation := range mutations {
+		if _, ok := mutation.(OneFifthRule); ok {
+			continue // the factor is not part of the name
+		}
+		got, err := ParseMutation(mutation.String())
+		if err != nil || got != mutation {
+			t.Errorf("ParseMutation(%q) = %v, %v", mutation.String(), got, err)
+		}
+	}
+	for _, s := range []string{"gaussian:0", "polynomial:-1", "non-uniform:x", "swap"} {
+		if _, err := ParseMutation(s); err == nil {
+			t.Errorf("ParseMutation(%q) succeeded", s)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package ga
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+// Optimizer adapts Run to optimize.Optimizer. Bounds and direction of the
+// problem override the ones in Config. With Config.Migration enabled,
+// Evaluate of the problem is called from several goroutines at once.
+type Optimizer struct {
+	Config Config
+}
+
+func (o Optimizer) Optimize(ctx context.Context, p optimize.Problem[[]float64]) (optimize.Result[[]float64], error) {
+	if p.Encoding() != optimize.Real || p.Dim() == 0 {
+		return optimize.Result[[]float64]{}, errors.New("ga: only real problems are supported")
+	}
+
+	cfg := o.Config
+	cfg.Lower, cfg.Upper = p.Bounds()
+	cfg.Direction = p.Direction()
+
+	start := time.Now()
+	res, err := Run(ctx, p.Evaluate, cfg)
+	return optimize.Result[[]float64]{
+		Best:        res.Best,
+		Value:       res.Value,
+		Evaluations: res.Evaluations,
+		Iterations:  res.Generations,
+		Reason:      res.Reason,
+		Elapsed:     time.Since(start),
+	}, err
+}
@@ -0,0 +1,197 @@
+package ga
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Replacement decides how many children are bred per generation and which
+// individuals form the next population. Scores follow the Selection
+// convention: larger is better.
+type Replacement interface {
+	// Offspring returns the number of children to breed for a population
+	// of size mu.
+	Offspring(mu int) int
+	// Survivors returns len(parents) indices into the concatenation of
+	// parents and offspring, parents first, drawing random numbers from r.
+	Survivors(r *rand.Rand, parents, offspring []float64) []int
+	String() string
+}
+
+// Generational replaces the whole population with its offspring, as in
+// Homework1 and Labwork1.
+type Generational struct{}
+
+func (Generational) Offspring(mu int) int { return mu }
+
+func (Generational) Survivors(r *rand.Rand, parents, offspring []float64) []int {
+	survivors := make([]int, len(parents))
+	for i := range survivors {
+		survivors[i] = len(parents) + i
+	}
+	return survivors
+}
+
+func (Generational) String() string { return "generational" }
+
+// Elitism copies the K best parents into the next population and fills the
+// rest with offspring.
+type Elitism struct {
+	K int
+}
+
+func (e Elitism) Offspring(mu int) int { return mu - min(e.K, mu) }
+
+func (e Elitism) Survivors(r *rand.Rand, parents, offspring []float64) []int {
+	k := min(e.K, len(parents))
+	survivors := append([]int(nil), bestFirst(parents)[:k]...)
+	for i := 0; len(survivors) < len(parents); i++ {
+		survivors = append(survivors, len(parents)+i)
+	}
+	return survivors
+}
+
+func (e Elitism) String() string { return fmt.Sprintf("elitism:%d", e.K) }
+
+// SteadyState breeds N children per generation, each replacing a randomly
+// chosen parent other than the best one.
+type SteadyState struct {
+	N int
+}
+
+func (s SteadyState) Offspring(mu int) int { return min(s.N, mu) }
+
+func (s SteadyState) Survivors(r *rand.Rand, parents, offspring []float64) []int {
+	survivors := make([]int, len(parents))
+	for i := range survivors {
+		survivors[i] = i
+	}
+	best := bestFirst(parents)[0]
+	candidates := make([]int, 0, len(parents)-1)
+	for i := range parents {
+		if i != best {
+			candidates = append(candidates, i)
+		}
+	}
+	r.Shuffle(len(candidates), func(a, b int) { candidates[a], candidates[b] = candidates[b], candidates[a] })
+	for i := range offspring {
+		if i < len(candidates) {
+			survivors[candidates[i]] = len(parents) + i
+		}
+	}
+	return survivors
+}
+
+func (s SteadyState) String() string { return fmt.Sprintf("steady-state:%d", s.N) }
+
+// ReplaceWorst breeds N children per generation; they replace the worst
+// parents they are better than.
+type ReplaceWorst struct {
+	N int
+}
+
+func (r ReplaceWorst) Offspring(mu int) int { return min(r.N, mu) }
+
+func (r ReplaceWorst) Survivors(_ *rand.Rand, parents, offspring []float64) []int {
+	return bestFirst(append(append([]float64(nil), parents...), offspring...))[:len(parents)]
+}
+
+func (r ReplaceWorst) String() string { return fmt.Sprintf("replace-worst:%d", r.N) }
+
+// MuPlusLambda breeds Lambda children and keeps the best mu of parents and
+// children together. Lambda = 0 means mu.
+type MuPlusLambda struct {
+	Lambda int
+}
+
+func (m MuPlusLambda) Offspring(mu int) int {
+	if m.Lambda > 0 {
+		return m.Lambda
+	}
+	return mu
+}
+
+func (m MuPlusLambda) Survivors(r *rand.Rand, parents, offspring []float64) []int {
+	return bestFirst(append(append([]float64(nil), parents...), offspring...))[:len(parents)]
+}
+
+func (m MuPlusLambda) String() string { return fmt.Sprintf("mu+lambda:%d", m.Lambda) }
+
+// MuCommaLambda breeds Lambda >= mu children and keeps the best mu of
+// them. Lambda = 0 means mu.
+type MuCommaLambda struct {
+	Lambda int
+}
+
+func (m MuCommaLambda) Offspring(mu int) int { return max(m.Lambda, mu) }
+
+func (m MuCommaLambda) Survivors(r *rand.Rand, parents, offspring []float64) []int {
+	survivors := bestFirst(offspring)[:len(parents)]
+	for i := range survivors {
+		survivors[i] += len(parents)
+	}
+	return survivors
+}
+
+func (m MuCommaLambda) String() string { return fmt.Sprintf("mu,lambda:%d", m.Lambda) }
+
+// ParseReplacement parses the names printed by the String methods, for
+// example "elitism:2" or "mu+lambda:100". The parameter may be omitted to
+// use its default.
+func ParseReplacement(s string) (Replacement, error) {
+	name, param, hasParam := strings.Cut(s, ":")
+	value := func(def, least int) (int, error) {
+		if !hasParam {
+			return def, nil
+		}
+		v, err := strconv.Atoi(param)
+		if err != nil || v < least {
+			return 0, fmt.Errorf("invalid parameter in replacement %q", s)
+		}
+		return v, nil
+	}
+
+	var v int
+	var err error
+	switch name {
+	case "generational":
+		return Generational{}, nil
+	case "elitism":
+		if v, err = value(1, 0); err == nil {
+			return Elitism{K: v}, nil
+		}
+	case "steady-state":
+		if v, err = value(2, 1); err == nil {
+			return SteadyState{N: v}, nil
+		}
+	case "replace-worst":
+		if v, err = value(2, 1); err == nil {
+			return ReplaceWorst{N: v}, nil
+		}
+	case "mu+lambda":
+		if v, err = value(0, 0); err == nil {
+			return MuPlusLambda{Lambda: v}, nil
+		}
+	case "mu,lambda":
+		if v, err = value(0, 0); err == nil {
+			return MuCommaLambda{Lambda: v}, nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown replacement %q", name)
+	}
+	return nil, err
+}
+
+// bestFirst returns the indices of scores from the best to the worst.
+// Among equal scores earlier indices come first.
+func bestFirst(scores []float64) []int {
+	order := make([]int, len(scores))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+	return order
+}
@@ -0,0 +1,128 @@
+package ga
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+var replacements = []Replacement{
+	Generational{}, Elitism{K: 0}, Elitism{K: 1}, Elitism{K: 5}, Elitism{K: 100},
+	SteadyState{N: 1}, SteadyState{N: 5}, SteadyState{N: 100},
+	ReplaceWorst{N: 2}, ReplaceWorst{N: 100},
+	MuPlusLambda{Lambda: 0}, MuPlusLambda{Lambda: 3}, MuPlusLambda{Lambda: 30},
+	MuCommaLambda{Lambda: 0}, MuCommaLambda{Lambda: 3}, MuCommaLambda{Lambda: 30},
+}
+
+func randomScores(r *rand.Rand, n int) []float64 {
+	scores := make([]float64, n)
+	for i := range scores {
+		switch r.Intn(5) {
+		case 0:
+			scores[i] = math.Inf(-1)
+		case 1:
+			scores[i] = 1 // ties
+		default:
+			scores[i] = r.NormFloat64()
+		}
+	}
+	return scores
+}
+
+func TestSurvivors(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, replacement := range replacements {
+		for _, mu := range []int{1, 2, 5, 10} {
+			lambda := replacement.Offspring(mu)
+			if lambda < 0 {
+				t.Fatalf("%s: Offspring(%d) = %d", replacement, mu, lambda)
+			}
+			for trial := 0; trial < 50; trial++ {
+				parents, offspring := randomScores(r, mu), randomScores(r, lambda)
+				survivors := replacement.Survivors(r, parents, offspring)
+				if len(survivors) != mu {
+					t.Fatalf("%s with mu=%d: %d survivors", replacement, mu, len(survivors))
+				}
+				seen := make(map[int]bool)
+				for _, i := range survivors {
+					if i < 0 || i >= mu+lambda {
+						t.Fatalf("%s with mu=%d, lambda=%d: survivor %d out of range", replacement, mu, lambda, i)
+					}
+					if seen[i] {
+						t.Fatalf("%s with mu=%d: survivor %d repeated", replacement, mu, i)
+					}
+					seen[i] = true
+				}
+			}
+		}
+	}
+}
+
+func TestSurvivorsKeepBest(t *testing.T) {
+	keepBest := []Replacement{
+		Elitism{K: 1}, Elitism{K: 100}, SteadyState{N: 5}, SteadyState{N: 100},
+		ReplaceWorst{N: 2}, MuPlusLambda{Lambda: 3},
+	}
+	r := rand.New(rand.NewSource(1))
+	for _, replacement := range keepBest {
+		for _, mu := range []int{1, 2, 5, 10} {
+			parents := randomScores(r, mu)
+			offspring := randomScores(r, replacement.Offspring(mu))
+			best := bestFirst(parents)[0]
+			kept := false
+			for _, i := range replacement.Survivors(r, parents, offspring) {
+				kept = kept || i == best || i >= mu && offspring[i-mu] >= parents[best]
+			}
+			if !kept {
+				t.Errorf("%s with mu=%d lost the best parent", replacement, mu)
+			}
+		}
+	}
+}
+
+func TestRunReplacements(t *testing.T) {
+	// NaN outside the unit ball gives the run -Inf scores to deal with.
+	fitness := func(x []float64) float64 {
+		sum := 0.0
+		for _, v := range x {
+			sum += v * v
+		}
+		if sum > 1 {
+			return math.NaN()
+		}
+		return sum
+	}
+	lower, upper := Bounds(3, -1, 1)
+	for _, replacement := range replacements {
+		cfg := DefaultConfig()
+		cfg.PopulationSize = 6
+		cfg.Replacement = replacement
+		cfg.Lower, cfg.Upper = lower, upper
+		cfg.MaxGenerations = 30
+		cfg.Direction = optimize.Minimize
+		res, err := Run(context.Background(), fitness, cfg)
+		if err != nil {
+			t.Fatalf("%s: %v", replacement, err)
+		}
+		if !inBounds(res.Best, lower, upper) || !(res.Value <= 1) {
+			t.Errorf("%s: best %v with value %g", replacement, res.Best, res.Value)
+		}
+	}
+}
+
+func TestParseReplacement(t *testing.T) {
+	for _, replacement := range replacements {
+		got, err := ParseReplacement(replacement.String())
+		if err != nil || got != replacement {
+			t.Errorf("ParseReplacement(%q) = %v, %v", replacement.String(), got, err)
+		}
+	}
+	for _, s := range []string{"elitism:-1", "steady-state:0", "mu+lambda:x", "tournament"} {
+		if _, err := ParseReplacement(s); err == nil {
+			t.Errorf("ParseReplacement(%q) succeeded", s)
+		}
+	}
+}
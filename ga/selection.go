@@ -0,0 +1,281 @@
+package ga
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Selection chooses parents for the next generation. scores holds one value
+// per individual, larger is better; -Inf marks individuals that must not
+// be chosen unless nothing else is available. Select returns n indices
+// into scores, drawing random numbers from r.
+type Selection interface {
+	Select(r *rand.Rand, scores []float64, n int) []int
+	String() string
+}
+
+// Tournament picks the best of Size individuals drawn uniformly.
+type Tournament struct {
+	Size int
+}
+
+func (t Tournament) Select(r *rand.Rand, scores []float64, n int) []int {
+	pool := admissible(scores)
+	picks := make([]int, n)
+	for k := range picks {
+		best := pool[r.Intn(len(pool))]
+		for i := 1; i < t.Size; i++ {
+			contender := pool[r.Intn(len(pool))]
+			if scores[contender] > scores[best] {
+				best = contender
+			}
+		}
+		picks[k] = best
+	}
+	return picks
+}
+
+func (t Tournament) String() string { return fmt.Sprintf("tournament:%d", t.Size) }
+
+// Roulette is fitness-proportional selection. Scores are shifted so that
+// the worst individual gets zero weight.
+type Roulette struct{}
+
+func (Roulette) Select(r *rand.Rand, scores []float64, n int) []int {
+	return sampleWeighted(r, shiftedWeights(scores), n)
+}
+
+func (Roulette) String() string { return "roulette" }
+
+// SUS is stochastic universal sampling over the same weights as Roulette:
+// n equally spaced pointers with a single random offset.
+type SUS struct{}
+
+func (SUS) Select(r *rand.Rand, scores []float64, n int) []int {
+	cumulative := cumulate(shiftedWeights(scores))
+	total := cumulative[len(cumulative)-1]
+	picks := make([]int, n)
+	step := total / float64(n)
+	pointer := r.Float64() * step
+	i := 0
+	for k := range picks {
+		for i < len(cumulative)-1 && cumulative[i] <= pointer {
+			i++
+		}
+		picks[k] = i
+		pointer += step
+	}
+	r.Shuffle(n, func(a, b int) { picks[a], picks[b] = picks[b], picks[a] })
+	return picks
+}
+
+func (SUS) String() string { return "sus" }
+
+// LinearRank gives the individual of rank r (0 is the worst) a probability
+// proportional to 2-s + 2(s-1)r/(N-1), where s = Pressure lies in [1, 2].
+type LinearRank struct {
+	Pressure float64
+}
+
+func (l LinearRank) Select(r *rand.Rand, scores []float64, n int) []int {
+	order := ranks(scores)
+	weights := make([]float64, len(scores))
+	size := float64(len(order))
+	for rank, i := range order {
+		weights[i] = 1
+		if size > 1 {
+			weights[i] = 2 - l.Pressure + 2*(l.Pressure-1)*float64(rank)/(size-1)
+		}
+	}
+	return sampleWeighted(r, weights, n)
+}
+
+func (l LinearRank) String() string { return fmt.Sprintf("linear-rank:%g", l.Pressure) }
+
+// ExponentialRank gives the k-th best individual a weight of Base^k, with
+// Base in (0, 1).
+type ExponentialRank struct {
+	Base float64
+}
+
+func (x ExponentialRank) Select(r *rand.Rand, scores []float64, n int) []int {
+	order := ranks(scores)
+	weights := make([]float64, len(scores))
+	for rank, i := range order {
+		weights[i] = math.Pow(x.Base, float64(len(order)-1-rank))
+	}
+	return sampleWeighted(r, weights, n)
+}
+
+func (x ExponentialRank) String() string { return fmt.Sprintf("exp-rank:%g", x.Base) }
+
+// Truncation picks uniformly among the best Fraction of the population.
+type Truncation struct {
+	Fraction float64
+}
+
+func (t Truncation) Select(r *rand.Rand, scores []float64, n int) []int {
+	order := ranks(scores)
+	keep := int(math.Ceil(t.Fraction * float64(len(order))))
+	keep = max(1, min(keep, len(order)))
+	best := order[len(order)-keep:]
+	picks := make([]int, n)
+	for k := range picks {
+		picks[k] = best[r.Intn(keep)]
+	}
+	return picks
+}
+
+func (t Truncation) String() string { return fmt.Sprintf("truncation:%g", t.Fraction) }
+
+// Boltzmann weights individuals by exp(score/Temperature). Lower
+// temperatures increase the selection pressure.
+type Boltzmann struct {
+	Temperature float64
+}
+
+func (b Boltzmann) Select(r *rand.Rand, scores []float64, n int) []int {
+	top := math.Inf(-1)
+	for _, s := range scores {
+		top = math.Max(top, s)
+	}
+	weights := make([]float64, len(scores))
+	for i, s := range scores {
+		if !math.IsInf(s, -1) {
+			weights[i] = math.Exp((s - top) / b.Temperature)
+		}
+	}
+	return sampleWeighted(r, weights, n)
+}
+
+func (b Boltzmann) String() string { return fmt.Sprintf("boltzmann:%g", b.Temperature) }
+
+// ParseSelection parses the names printed by the String methods, for
+// example "tournament:3", "sus" or "boltzmann:10". The parameter may be
+// omitted to use its default.
+func ParseSelection(s string) (Selection, error) {
+	name, param, hasParam := strings.Cut(s, ":")
+	value := func(def float64) (float64, error) {
+		if !hasParam {
+			return def, nil
+		}
+		return strconv.ParseFloat(param, 64)
+	}
+
+	var v float64
+	var err error
+	switch name {
+	case "tournament":
+		if v, err = value(3); err == nil && v >= 1 && v == math.Trunc(v) {
+			return Tournament{Size: int(v)}, nil
+		}
+	case "roulette":
+		return Roulette{}, nil
+	case "sus":
+		return SUS{}, nil
+	case "linear-rank":
+		if v, err = value(1.5); err == nil && v >= 1 && v <= 2 {
+			return LinearRank{Pressure: v}, nil
+		}
+	case "exp-rank":
+		if v, err = value(0.9); err == nil && v > 0 && v < 1 {
+			return ExponentialRank{Base: v}, nil
+		}
+	case "truncation":
+		if v, err = value(0.5); err == nil && v > 0 && v <= 1 {
+			return Truncation{Fraction: v}, nil
+		}
+	case "boltzmann":
+		if v, err = value(1); err == nil && v > 0 {
+			return Boltzmann{Temperature: v}, nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown selection %q", name)
+	}
+	return nil, fmt.Errorf("invalid parameter in selection %q", s)
+}
+
+// admissible returns the indices of the individuals scored above -Inf, or
+// of all individuals if there are none.
+func admissible(scores []float64) []int {
+	pool := make([]int, 0, len(scores))
+	for i, s := range scores {
+		if !math.IsInf(s, -1) {
+			pool = append(pool, i)
+		}
+	}
+	if len(pool) == 0 {
+		for i := range scores {
+			pool = append(pool, i)
+		}
+	}
+	return pool
+}
+
+// ranks returns the admissible indices of scores ordered from the worst to
+// the best.
+func ranks(scores []float64) []int {
+	order := admissible(scores)
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] < scores[order[b]] })
+	return order
+}
+
+// shiftedWeights subtracts the worst finite score from every score.
+// Individuals with a score of -Inf get zero weight; if every other
+// individual has the same score, they all get a weight of one.
+func shiftedWeights(scores []float64) []float64 {
+	worst := math.Inf(1)
+	for _, s := range scores {
+		if !math.IsInf(s, 0) {
+			worst = math.Min(worst, s)
+		}
+	}
+	weights := make([]float64, len(scores))
+	spread := false
+	for i, s := range scores {
+		if !math.IsInf(s, -1) {
+			weights[i] = s - worst
+			spread = spread || weights[i] > 0
+		}
+	}
+	if !spread {
+		for i, s := range scores {
+			if !math.IsInf(s, -1) {
+				weights[i] = 1
+			}
+		}
+	}
+	return weights
+}
+
+func cumulate(weights []float64) []float64 {
+	cumulative := make([]float64, len(weights))
+	total := 0.0
+	for i, w := range weights {
+		total += w
+		cumulative[i] = total
+	}
+	if total <= 0 || math.IsInf(total, 0) || math.IsNaN(total) {
+		for i := range cumulative {
+			cumulative[i] = float64(i + 1)
+		}
+	}
+	return cumulative
+}
+
+// sampleWeighted draws n indices with probabilities proportional to
+// weights, falling back to uniform sampling if all weights are zero.
+func sampleWeighted(r *rand.Rand, weights []float64, n int) []int {
+	cumulative := cumulate(weights)
+	total := cumulative[len(cumulative)-1]
+	picks := make([]int, n)
+	for k := range picks {
+		x := r.Float64() * total
+		picks[k] = sort.Search(len(cumulative)-1, func(i int) bool { return cumulative[i] > x })
+	}
+	return picks
+}
@@ -0,0 +1,89 @@
+package ga
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+var selections = []Selection{
+	Tournament{Size: 1}, Tournament{Size: 3}, Roulette{}, SUS{},
+	LinearRank{Pressure: 1}, LinearRank{Pressure: 2}, ExponentialRank{Base: 0.9},
+	Truncation{Fraction: 0.5}, Truncation{Fraction: 1}, Boltzmann{Temperature: 1},
+}
+
+func TestSelectionSkipsInfeasible(t *testing.T) {
+	inf := math.Inf(-1)
+	tests := []struct {
+		name   string
+		scores []float64
+	}{
+		{"one finite", []float64{inf, inf, 3, inf, inf}},
+		{"equal finite", []float64{inf, 2, inf, 2, 2, inf}},
+		{"mixed", []float64{-5, inf, 0, 7, inf, -1e9, 1e9}},
+		{"worst finite only", []float64{inf, -1, 4, inf}},
+	}
+	r := rand.New(rand.NewSource(1))
+	for _, selection := range selections {
+		for _, tt := range tests {
+			for _, n := range []int{0, 1, 2, 7, 50} {
+				picks := selection.Select(r, tt.scores, n)
+				if len(picks) != n {
+					t.Fatalf("%s on %s: %d picks, want %d", selection, tt.name, len(picks), n)
+				}
+				for _, i := range picks {
+					if i < 0 || i >= len(tt.scores) {
+						t.Fatalf("%s on %s: index %d out of range", selection, tt.name, i)
+					}
+					if math.IsInf(tt.scores[i], -1) {
+						t.Fatalf("%s on %s: picked %d scored -Inf", selection, tt.name, i)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSelectionAllInfeasible(t *testing.T) {
+	scores := []float64{math.Inf(-1), math.Inf(-1), math.Inf(-1)}
+	r := rand.New(rand.NewSource(1))
+	for _, selection := range selections {
+		for _, i := range selection.Select(r, scores, 20) {
+			if i < 0 || i >= len(scores) {
+				t.Fatalf("%s: index %d out of range", selection, i)
+			}
+		}
+	}
+}
+
+func TestSelectionPrefersBetter(t *testing.T) {
+	scores := []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	r := rand.New(rand.NewSource(1))
+	for _, selection := range selections {
+		switch selection {
+		case Tournament{Size: 1}, LinearRank{Pressure: 1}, Truncation{Fraction: 1}:
+			continue // uniform by definition
+		}
+		counts := make([]int, len(scores))
+		for _, i := range selection.Select(r, scores, 10000) {
+			counts[i]++
+		}
+		if counts[len(counts)-1] <= counts[0] {
+			t.Errorf("%s picked the best %d times and the worst %d times", selection, counts[len(counts)-1], counts[0])
+		}
+	}
+}
+
+func TestParseSelection(t *testing.T) {
+	for _, selection := range selections {
+		got, err := ParseSelection(selection.String())
+		if err != nil || got != selection {
+			t.Errorf("ParseSelection(%q) = %v, %v", selection.String(), got, err)
+		}
+	}
+	for _, s := range []string{"tournament:0", "tournament:2.5", "linear-rank:3", "exp-rank:1", "truncation:0", "boltzmann:-1", "best"} {
+		if _, err := ParseSelection(s); err == nil {
+			t.Errorf("ParseSelection(%q) succeeded", s)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/alexe14ernyakov/BioinspiredAlgorithms
+
+go 1.22
@@ -0,0 +1,151 @@
+package knapsack
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// BranchAndBound solves the same problem as BruteForce with a depth-first
+// search over the items sorted by weight. A branch is cut as soon as the
+// next item does not fit, since no heavier item fits either, or when even
+// all remaining items cannot reach the weight being looked for. The first
+// search stops at an exact hit and gives FirstSolutionTime; the second
+// collects every subset with the optimal weight and gives
+// AllSolutionsTime. It returns the same Solution, with the combinations
+// in the same order.
+func BranchAndBound(ctx context.Context, items []Item, target int) (Solution, error) {
+	startAll := time.Now()
+	solution := Solution{Combinations: [][]int{}}
+	if target < 0 {
+		return solution, nil
+	}
+
+	// order holds the positions of the items in ascending order of weight
+	// and rest[k] the total weight of order[k:].
+	n := len(items)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return items[order[a]].Weight < items[order[b]].Weight
+	})
+	rest := make([]int, n+1)
+	for k := n - 1; k >= 0; k-- {
+		rest[k] = rest[k+1] + items[order[k]].Weight
+	}
+
+	var err error
+	visited := 0
+	cancelled := func() bool {
+		if err != nil {
+			return true
+		}
+		if visited++; visited%cancelCheckInterval == 0 {
+			err = ctx.Err()
+		}
+		return err != nil
+	}
+
+	// improve looks for the heaviest subset not exceeding target and
+	// returns true once it hits target exactly.
+	best := 0
+	var chosen, bestChosen []int
+	var improve func(k, weight int) bool
+	improve = func(k, weight int) bool {
+		if cancelled() {
+			return true
+		}
+		if weight > best {
+			best = weight
+			bestChosen = append(bestChosen[:0], chosen...)
+		}
+		if weight == target {
+			return true
+		}
+		for ; k < n && weight+rest[k] > best; k++ {
+			w := items[order[k]].Weight
+			if weight+w > target {
+				break
+			}
+			chosen = append(chosen, order[k])
+			hit := improve(k+1, weight+w)
+			chosen = chosen[:len(chosen)-1]
+			if hit {
+				return true
+			}
+		}
+		return false
+	}
+	improve(0, 0)
+	if err != nil {
+		return solution, err
+	}
+
+	solution.AchievedWeight = best
+	if best > 0 {
+		solution.FirstCombination = positionIndices(items, bestChosen)
+		solution.FirstWeight = best
+		solution.FirstSolutionTime = float64(time.Since(startAll).Microseconds()) / 1000
+	}
+
+	// collect finds every subset weighing exactly best.
+	var found [][]int
+	var collect func(k, weight int)
+	collect = func(k, weight int) {
+		if cancelled() {
+			return
+		}
+		if weight == best {
+			found = append(found, sortedCopy(chosen))
+			return
+		}
+		for ; k < n && weight+rest[k] >= best; k++ {
+			w := items[order[k]].Weight
+			if weight+w > best {
+				break
+			}
+			chosen = append(chosen, order[k])
+			collect(k+1, weight+w)
+			chosen = chosen[:len(chosen)-1]
+		}
+	}
+	collect(0, 0)
+
+	sort.Slice(found, func(a, b int) bool { return maskLess(found[a], found[b]) })
+	for _, positions := range found {
+		solution.Combinations = append(solution.Combinations, positionIndices(items, positions))
+	}
+	solution.AllSolutionsTime = float64(time.Since(startAll).Microseconds()) / 1000
+	return solution, err
+}
+
+// positionIndices returns the indices of the items at the given positions,
+// in position order.
+func positionIndices(items []Item, positions []int) []int {
+	positions = sortedCopy(positions)
+	indices := make([]int, len(positions))
+	for k, pos := range positions {
+		indices[k] = items[pos].Index
+	}
+	return indices
+}
+
+func sortedCopy(s []int) []int {
+	c := append([]int(nil), s...)
+	sort.Ints(c)
+	return c
+}
+
+// maskLess reports whether the subset of ascending positions a comes
+// before b in the mask order BruteForce enumerates subsets in.
+func maskLess(a, b []int) bool {
+	i, j := len(a)-1, len(b)-1
+	for ; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if a[i] != b[j] {
+			return a[i] < b[j]
+		}
+	}
+	return i < j
+}
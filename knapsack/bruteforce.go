@@ -0,0 +1,204 @@
+package knapsack
+
+import (
+	"context"
+	"time"
+)
+
+// cancelCheckInterval is how many masks are enumerated between checks of
+// the context.
+const cancelCheckInterval = 1 << 16
+
+// ExactSolver returns every subset of items with the largest weight not
+// exceeding target.
+type ExactSolver func(ctx context.Context, items []Item, target int) (Solution, error)
+
+// ExactSolvers maps the names accepted by the command-line tools to the
+// exact subset-sum solvers.
+var ExactSolvers = map[string]ExactSolver{
+	"brute":    BruteForce,
+	"bnb":      BranchAndBound,
+	"dp":       DynamicProgramming,
+	"gray":     GrayCodeBruteForce,
+	"parallel": ParallelBruteForce,
+	"mitm":     MeetInTheMiddle,
+	"mitm-stream": func(ctx context.Context, items []Item, target int) (Solution, error) {
+		return StreamMeetInTheMiddle(ctx, items, target, DefaultCombinationLimit)
+	},
+}
+
+type Solution struct {
+	AchievedWeight    int
+	Combinations      [][]int
+	FirstSolutionTime float64
+	AllSolutionsTime  float64
+
+	// FirstCombination is the first improving combination found, reported
+	// together with FirstSolutionTime.
+	FirstCombination []int
+	FirstWeight      int
+
+	// AchievedValue is the optimal total value found by BruteForceValue.
+	// AchievedWeight then holds the first-dimension weight of
+	// Combinations[0].
+	AchievedValue int
+
+	// Omitted counts the optimal combinations that were found but not
+	// stored in Combinations because the solver caps how many it keeps.
+	Omitted int
+}
+
+// Count returns the number of optimal combinations, including the
+// omitted ones.
+func (s Solution) Count() int {
+	return len(s.Combinations) + s.Omitted
+}
+
+// BruteForce enumerates every subset of items and returns all subsets with
+// the largest weight not exceeding target. If ctx is cancelled it returns
+// the solutions found so far and ctx.Err().
+func BruteForce(ctx context.Context, items []Item, target int) (Solution, error) {
+	n := len(items)
+	maxWeight := 0
+	solutions := make([][]int, 0)
+
+	startAll := time.Now()
+	var firstSolutionTime time.Time
+	var firstCombination []int
+	firstWeight := 0
+	found := false
+	var err error
+
+	for mask := 0; mask < (1 << uint(n)); mask++ {
+		if mask%cancelCheckInterval == 0 {
+			if err = ctx.Err(); err != nil {
+				break
+			}
+		}
+
+		currentWeight := 0
+		var currentCombination []int
+
+		for i := 0; i < n; i++ {
+			if mask&(1<<uint(i)) != 0 {
+				currentWeight += items[i].Weight
+				currentCombination = append(currentCombination, items[i].Index)
+			}
+
+			if currentWeight > target {
+				break
+			}
+		}
+
+		if currentWeight > target {
+			continue
+		}
+
+		if currentWeight > maxWeight {
+			maxWeight = currentWeight
+			solutions = [][]int{currentCombination}
+			if !found {
+				firstSolutionTime = time.Now()
+				firstCombination = currentCombination
+				firstWeight = maxWeight
+				found = true
+			}
+		} else if currentWeight == maxWeight {
+			solutions = append(solutions, currentCombination)
+		}
+	}
+
+	allTime := time.Since(startAll)
+	var firstTime time.Duration
+	if found {
+		firstTime = firstSolutionTime.Sub(startAll)
+	}
+
+	return Solution{
+		AchievedWeight:    maxWeight,
+		Combinations:      solutions,
+		FirstSolutionTime: float64(firstTime.Microseconds()) / 1000,
+		AllSolutionsTime:  float64(allTime.Microseconds()) / 1000,
+		FirstCombination:  firstCombination,
+		FirstWeight:       firstWeight,
+	}, err
+}
+
+// BruteForceValue enumerates every subset of items and returns all
+// subsets with the largest value that fit capacities in every dimension.
+// If ctx is cancelled it returns the solutions found so far and ctx.Err().
+func BruteForceValue(ctx context.Context, items []ValuedItem, capacities []int) (Solution, error) {
+	n := len(items)
+	maxValue := 0
+	maxWeight := 0
+	solutions := make([][]int, 0)
+	weights := make([]int, len(capacities))
+
+	startAll := time.Now()
+	var firstSolutionTime time.Time
+	var firstCombination []int
+	firstWeight := 0
+	found := false
+	var err error
+
+	for mask := 0; mask < (1 << uint(n)); mask++ {
+		if mask%cancelCheckInterval == 0 {
+			if err = ctx.Err(); err != nil {
+				break
+			}
+		}
+
+		currentValue := 0
+		clear(weights)
+		feasible := true
+		var currentCombination []int
+
+		for i := 0; i < n && feasible; i++ {
+			if mask&(1<<uint(i)) == 0 {
+				continue
+			}
+			currentValue += items[i].Value
+			currentCombination = append(currentCombination, items[i].Index)
+			for d, w := range items[i].Weights {
+				weights[d] += w
+				if weights[d] > capacities[d] {
+					feasible = false
+				}
+			}
+		}
+
+		if !feasible {
+			continue
+		}
+
+		if currentValue > maxValue {
+			maxValue = currentValue
+			maxWeight = weights[0]
+			solutions = [][]int{currentCombination}
+			if !found {
+				firstSolutionTime = time.Now()
+				firstCombination = currentCombination
+				firstWeight = maxWeight
+				found = true
+			}
+		} else if currentValue == maxValue {
+			solutions = append(solutions, currentCombination)
+		}
+	}
+
+	allTime := time.Since(startAll)
+	var firstTime time.Duration
+	if found {
+		firstTime = firstSolutionTime.Sub(startAll)
+	}
+
+	return Solution{
+		AchievedWeight:    maxWeight,
+		AchievedValue:     maxValue,
+		Combinations:      solutions,
+		FirstSolutionTime: float64(firstTime.Microseconds()) / 1000,
+		AllSolutionsTime:  float64(allTime.Microseconds()) / 1000,
+		FirstCombination:  firstCombination,
+		FirstWeight:       firstWeight,
+	}, err
+}
@@ -0,0 +1,78 @@
+package knapsack
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"testing"
+)
+
+// checkExactSolvers compares every solver in ExactSolvers with BruteForce.
+// Solvers that cap the kept combinations must keep the first ones.
+func checkExactSolvers(t *testing.T, items []Item, target int) {
+	t.Helper()
+	want, err := BruteForce(context.Background(), items, target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, solve := range ExactSolvers {
+		got, err := solve(context.Background(), items, target)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		kept := want.Combinations[:max(len(want.Combinations)-got.Omitted, 0)]
+		if got.AchievedWeight != want.AchievedWeight || got.Count() != want.Count() ||
+			fmt.Sprint(got.Combinations) != fmt.Sprint(kept) {
+			t.Fatalf("%s on %v with target %d: weight %d and %d combinations, want %d and %d",
+				name, items, target, got.AchievedWeight, got.Count(), want.AchievedWeight, want.Count())
+		}
+	}
+
+	limited, err := StreamMeetInTheMiddle(context.Background(), items, target, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept := want.Combinations[:min(2, len(want.Combinations))]
+	if fmt.Sprint(limited.Combinations) != fmt.Sprint(kept) || limited.Count() != want.Count() {
+		t.Fatalf("StreamMeetInTheMiddle with limit 2: combinations %v and count %d, want %v and %d",
+			limited.Combinations, limited.Count(), kept, want.Count())
+	}
+}
+
+func TestExactSolversMatchBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 200; trial++ {
+		// Small weights give many optimal combinations to order.
+		n := 1 + r.Intn(14)
+		items, target := randomInstance(r, n, 1+r.Intn(60))
+		switch trial % 10 {
+		case 0:
+			target = 0
+		case 1:
+			target += 1000
+		case 2:
+			target--
+		}
+		checkExactSolvers(t, items, target)
+	}
+}
+
+// TestExactSolversMatchBruteForceLarge uses more masks than fit in one
+// chunk of ParallelBruteForce, so that its workers share the best weight
+// and their chunks are merged.
+func TestExactSolversMatchBruteForceLarge(t *testing.T) {
+	if testing.Short() {
+		t.Skip("enumerates up to 2^20 subsets per solver")
+	}
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	r := rand.New(rand.NewSource(2))
+	for n := 17; n <= 20; n++ {
+		items, target := randomInstance(r, n, 200)
+		checkExactSolvers(t, items, target)
+		// Small weights make the optimal combinations span many chunks.
+		items, target = randomInstance(r, n, 8)
+		checkExactSolvers(t, items, target/2)
+	}
+}
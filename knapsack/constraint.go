@@ -0,0 +1,169 @@
+package knapsack
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Constraint turns the weight of a subset into the fitness minimised by
+// GeneticAlgorithm and decides how subsets heavier than the target are
+// treated. Fitness must be zero only for weight == target.
+type Constraint interface {
+	Fitness(weight, target int) int
+	String() string
+}
+
+// Repairer is implemented by constraints that make every chromosome
+// feasible before it is scored. Repair returns the repaired chromosome and
+// the number of genes it changed; the genome of c is not modified.
+type Repairer interface {
+	Repair(c Chromosome, items []Item, target int) (Chromosome, int)
+}
+
+// AdaptiveConstraint is implemented by constraints that change between
+// generations. Adapt receives the share of feasible chromosomes in the
+// population and returns the constraint for the next generation.
+type AdaptiveConstraint interface {
+	Adapt(feasibleRate float64) Constraint
+}
+
+// Distance is the original fitness |target - weight|: overweight and
+// underweight subsets are treated alike.
+type Distance struct{}
+
+func (Distance) Fitness(weight, target int) int { return distance(weight, target) }
+
+func (Distance) String() string { return "distance" }
+
+// Reject ranks every overweight subset below the empty one, so the search
+// is driven towards feasible subsets only.
+type Reject struct{}
+
+func (Reject) Fitness(weight, target int) int {
+	if weight > target {
+		return target + 1
+	}
+	return target - weight
+}
+
+func (Reject) String() string { return "reject" }
+
+// Repair drops items from overweight subsets until they fit. Among the
+// selected items it removes the lightest one that restores feasibility on
+// its own, or the heaviest one if there is none, and repeats.
+type Repair struct{}
+
+func (Repair) Fitness(weight, target int) int { return Reject{}.Fitness(weight, target) }
+
+func (Repair) Repair(c Chromosome, items []Item, target int) (Chromosome, int) {
+	changed := 0
+	for c.Weight > target {
+		excess := c.Weight - target
+		drop, heaviest := -1, -1
+		c.Genes.ForEachSet(func(i int) {
+			w := items[i].Weight
+			if w >= excess && (drop < 0 || w < items[drop].Weight) {
+				drop = i
+			}
+			if heaviest < 0 || w > items[heaviest].Weight {
+				heaviest = i
+			}
+		})
+		if drop < 0 {
+			drop = heaviest
+		}
+		if changed == 0 {
+			c.Genes = c.Genes.Clone()
+		}
+		c.Genes.Flip(drop)
+		c.Weight -= items[drop].Weight
+		changed++
+	}
+	c.Fitness = target - c.Weight
+	return c, changed
+}
+
+func (Repair) String() string { return "repair" }
+
+// StaticPenalty multiplies the excess weight of overweight subsets by
+// Coefficient. Coefficients above one favour feasible subsets.
+type StaticPenalty struct {
+	Coefficient float64
+}
+
+func (s StaticPenalty) Fitness(weight, target int) int {
+	return penalty(weight, target, s.Coefficient)
+}
+
+func (s StaticPenalty) String() string { return fmt.Sprintf("static-penalty:%g", s.Coefficient) }
+
+// AdaptivePenalty is StaticPenalty whose Coefficient is multiplied by
+// Factor when fewer than half of the population is feasible and divided by
+// it when more than half is.
+type AdaptivePenalty struct {
+	Coefficient float64
+	Factor      float64
+}
+
+func (a AdaptivePenalty) Fitness(weight, target int) int {
+	return penalty(weight, target, a.Coefficient)
+}
+
+func (a AdaptivePenalty) Adapt(feasibleRate float64) Constraint {
+	switch {
+	case feasibleRate < 0.5:
+		a.Coefficient *= a.Factor
+	case feasibleRate > 0.5:
+		a.Coefficient /= a.Factor
+	}
+	return a
+}
+
+func (a AdaptivePenalty) String() string { return fmt.Sprintf("adaptive-penalty:%g", a.Coefficient) }
+
+func penalty(weight, target int, coefficient float64) int {
+	if weight <= target {
+		return target - weight
+	}
+	return int(math.Ceil(coefficient * float64(weight-target)))
+}
+
+// feasibleRate returns the share of population whose weight fits target.
+func feasibleRate(population []Chromosome, target int) float64 {
+	feasible := 0
+	for _, c := range population {
+		if c.Weight <= target {
+			feasible++
+		}
+	}
+	return float64(feasible) / float64(len(population))
+}
+
+// ParseConstraint parses the names printed by the String methods, for
+// example "static-penalty:2". The parameter may be omitted to use its
+// default.
+func ParseConstraint(s string) (Constraint, error) {
+	name, param, hasParam := strings.Cut(s, ":")
+	coefficient := 2.0
+	if hasParam {
+		var err error
+		if coefficient, err = strconv.ParseFloat(param, 64); err != nil || coefficient <= 0 {
+			return nil, fmt.Errorf("invalid parameter in constraint %q", s)
+		}
+	}
+	switch name {
+	case "distance":
+		return Distance{}, nil
+	case "reject":
+		return Reject{}, nil
+	case "repair":
+		return Repair{}, nil
+	case "static-penalty":
+		return StaticPenalty{Coefficient: coefficient}, nil
+	case "adaptive-penalty":
+		return AdaptivePenalty{Coefficient: coefficient, Factor: 1.2}, nil
+	}
+	return nil, fmt.Errorf("unknown constraint %q", name)
+}
@@ -0,0 +1,26 @@
+package knapsack
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRepairKeepsParent(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 100; trial++ {
+		items, target := randomInstance(r, 40, 1000)
+		c := CalculateFitness(Chromosome{Genes: randomGenome(r, len(items))}, items, target)
+		want := c.Genes.Clone()
+
+		repaired, _ := Repair{}.Repair(c, items, target)
+		if !c.Genes.Equal(want) {
+			t.Fatalf("Repair modified the genes of its argument")
+		}
+		if repaired.Weight > target {
+			t.Fatalf("Repair left weight %d above target %d", repaired.Weight, target)
+		}
+		if got := CalculateFitness(Chromosome{Genes: repaired.Genes}, items, target); got.Weight != repaired.Weight {
+			t.Fatalf("Repair reported weight %d, genes weigh %d", repaired.Weight, got.Weight)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package knapsack
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Crossover recombines two genomes of equal length into two new genomes,
+// drawing random numbers from r. The parents are not modified.
+type Crossover interface {
+	Cross(r *rand.Rand, p1, p2 Genome) (c1, c2 Genome)
+	String() string
+}
+
+// swap exchanges gene i between the children.
+func swap(c1, c2 Genome, i int) {
+	v1, v2 := c1.Get(i), c2.Get(i)
+	c1.Set(i, v2)
+	c2.Set(i, v1)
+}
+
+// OnePoint swaps the tails of the parents after a random point.
+type OnePoint struct{}
+
+func (OnePoint) Cross(r *rand.Rand, p1, p2 Genome) (Genome, Genome) {
+	crossoverPoint := r.Intn(p1.Len())
+	c1, c2 := p1.Clone(), p2.Clone()
+	swapRange(c1, c2, crossoverPoint, p1.Len())
+	return c1, c2
+}
+
+func (OnePoint) String() string { return "one-point" }
+
+// TwoPoint swaps the segment between two random points.
+type TwoPoint struct{}
+
+func (TwoPoint) Cross(r *rand.Rand, p1, p2 Genome) (Genome, Genome) { return kPoint(r, p1, p2, 2) }
+
+func (TwoPoint) String() string { return "two-point" }
+
+// KPoint cuts the parents at K distinct random points and swaps every
+// other segment.
+type KPoint struct {
+	K int
+}
+
+func (k KPoint) Cross(r *rand.Rand, p1, p2 Genome) (Genome, Genome) { return kPoint(r, p1, p2, k.K) }
+
+func (k KPoint) String() string { return fmt.Sprintf("k-point:%d", k.K) }
+
+func kPoint(r *rand.Rand, p1, p2 Genome, k int) (Genome, Genome) {
+	c1, c2 := p1.Clone(), p2.Clone()
+	if p1.Len() < 2 {
+		return c1, c2
+	}
+	k = min(k, p1.Len()-1)
+	points := r.Perm(p1.Len() - 1)[:k]
+	for i := range points {
+		points[i]++
+	}
+	sort.Ints(points)
+
+	points = append(points, p1.Len())
+	for i := 0; i+1 < len(points); i += 2 {
+		swapRange(c1, c2, points[i], points[i+1])
+	}
+	return c1, c2
+}
+
+// Uniform swaps every gene independently with probability SwapProb.
+type Uniform struct {
+	SwapProb float64
+}
+
+func (u Uniform) Cross(r *rand.Rand, p1, p2 Genome) (Genome, Genome) {
+	c1, c2 := p1.Clone(), p2.Clone()
+	mask := NewGenome(p1.Len())
+	if u.SwapProb == 0.5 {
+		for i := range mask.words {
+			mask.words[i] = r.Uint64()
+		}
+	} else {
+		forEachBernoulli(r, p1.Len(), u.SwapProb, func(i int) { mask.Flip(i) })
+	}
+	swapMask(c1, c2, mask.words)
+	return c1, c2
+}
+
+func (u Uniform) String() string { return fmt.Sprintf("uniform:%g", u.SwapProb) }
+
+// HalfUniform (HUX) swaps exactly half of the genes in which the parents
+// differ.
+type HalfUniform struct{}
+
+func (HalfUniform) Cross(r *rand.Rand, p1, p2 Genome) (Genome, Genome) {
+	c1, c2 := p1.Clone(), p2.Clone()
+	var diff []int
+	p1.forEachDiff(p2, func(i int) { diff = append(diff, i) })
+	r.Shuffle(len(diff), func(a, b int) { diff[a], diff[b] = diff[b], diff[a] })
+	for _, i := range diff[:len(diff)/2] {
+		swap(c1, c2, i)
+	}
+	return c1, c2
+}
+
+func (HalfUniform) String() string { return "half-uniform" }
+
+// Shuffle is one-point crossover applied after a random permutation of the
+// genes, which removes the positional bias of OnePoint.
+type Shuffle struct{}
+
+func (Shuffle) Cross(r *rand.Rand, p1, p2 Genome) (Genome, Genome) {
+	c1, c2 := p1.Clone(), p2.Clone()
+	perm := r.Perm(p1.Len())
+	for _, i := range perm[r.Intn(p1.Len()):] {
+		swap(c1, c2, i)
+	}
+	return c1, c2
+}
+
+func (Shuffle) String() string { return "shuffle" }
+
+// ParseCrossover parses the names printed by the String methods, for
+// example "k-point:3" or "uniform:0.5". The parameter may be omitted to
+// use its default.
+func ParseCrossover(s string) (Crossover, error) {
+	name, param, hasParam := strings.Cut(s, ":")
+	switch name {
+	case "one-point":
+		return OnePoint{}, nil
+	case "two-point":
+		return TwoPoint{}, nil
+	case "k-point":
+		k := 3
+		if hasParam {
+			var err error
+			if k, err = strconv.Atoi(param); err != nil || k < 1 {
+				return nil, fmt.Errorf("invalid parameter in crossover %q", s)
+			}
+		}
+		return KPoint{K: k}, nil
+	case "uniform":
+		p := 0.5
+		if hasParam {
+			var err error
+			if p, err = strconv.ParseFloat(param, 64); err != nil || p < 0 || p > 1 {
+				return nil, fmt.Errorf("invalid parameter in crossover %q", s)
+			}
+		}
+		return Uniform{SwapProb: p}, nil
+	case "half-uniform":
+		return HalfUniform{}, nil
+	case "shuffle":
+		return Shuffle{}, nil
+	}
+	return nil, fmt.Errorf("unknown crossover %q", name)
+}
@@ -0,0 +1,40 @@
+package knapsack
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func randomGenome(r *rand.Rand, n int) Genome {
+	g := NewGenome(n)
+	for i := 0; i < n; i++ {
+		g.Set(i, r.Intn(2) == 1)
+	}
+	return g
+}
+
+func TestCrossoverKeepsParents(t *testing.T) {
+	crossovers := []Crossover{
+		OnePoint{}, TwoPoint{}, KPoint{K: 3}, Uniform{SwapProb: 0.5},
+		Uniform{SwapProb: 0.2}, HalfUniform{}, Shuffle{},
+	}
+	r := rand.New(rand.NewSource(1))
+	for _, crossover := range crossovers {
+		for _, n := range []int{1, 24, 64, 130} {
+			for trial := 0; trial < 20; trial++ {
+				p1, p2 := randomGenome(r, n), randomGenome(r, n)
+				want1, want2 := p1.Clone(), p2.Clone()
+
+				c1, c2 := crossover.Cross(r, p1, p2)
+				// The children must not share storage with the parents either.
+				for i := 0; i < n; i++ {
+					c1.Flip(i)
+					c2.Flip(i)
+				}
+				if !p1.Equal(want1) || !p2.Equal(want2) {
+					t.Fatalf("%s modified the parents of length %d", crossover, n)
+				}
+			}
+		}
+	}
+}
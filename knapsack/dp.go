@@ -0,0 +1,111 @@
+package knapsack
+
+import (
+	"context"
+	"math/bits"
+	"time"
+)
+
+// DynamicProgramming solves the same problem as BruteForce with a bitset
+// of reachable sums per item prefix: the sums reachable with the first i
+// items are those of the first i-1 items, shifted and unshifted by the
+// weight of item i. It returns the same Solution, with the combinations
+// in the same order, using O(n·target/64) words of memory.
+// FirstCombination is the first optimal combination.
+func DynamicProgramming(ctx context.Context, items []Item, target int) (Solution, error) {
+	startAll := time.Now()
+	if target < 0 {
+		return Solution{Combinations: [][]int{}}, nil
+	}
+
+	// reach[i] holds the sums in [0, target] reachable with items[:i].
+	n := len(items)
+	reach := make([][]uint64, n+1)
+	reach[0] = make([]uint64, target/64+1)
+	reach[0][0] = 1
+	for i, item := range items {
+		if err := ctx.Err(); err != nil {
+			return Solution{Combinations: [][]int{}}, err
+		}
+		if item.Weight > target {
+			reach[i+1] = reach[i]
+			continue
+		}
+		reach[i+1] = shiftOr(reach[i], item.Weight, target)
+	}
+
+	best := highestBit(reach[n])
+	solution := Solution{AchievedWeight: best, Combinations: [][]int{}}
+	isSet := func(set []uint64, s int) bool { return set[s/64]&(1<<uint(s%64)) != 0 }
+
+	// walk decides items[i-1], ..., items[0] so that they add up to s,
+	// skipping an item before taking it, which yields the combinations in
+	// increasing mask order like BruteForce.
+	var chosen []int
+	var err error
+	visited := 0
+	var walk func(i, s int)
+	walk = func(i, s int) {
+		if err != nil {
+			return
+		}
+		if visited++; visited%cancelCheckInterval == 0 {
+			if err = ctx.Err(); err != nil {
+				return
+			}
+		}
+		if i == 0 {
+			combination := make([]int, len(chosen))
+			for k, pos := range chosen {
+				combination[len(chosen)-1-k] = items[pos].Index
+			}
+			if len(solution.Combinations) == 0 {
+				solution.FirstSolutionTime = float64(time.Since(startAll).Microseconds()) / 1000
+				solution.FirstCombination = combination
+				solution.FirstWeight = best
+			}
+			solution.Combinations = append(solution.Combinations, combination)
+			return
+		}
+		if isSet(reach[i-1], s) {
+			walk(i-1, s)
+		}
+		if w := items[i-1].Weight; s >= w && isSet(reach[i-1], s-w) {
+			chosen = append(chosen, i-1)
+			walk(i-1, s-w)
+			chosen = chosen[:len(chosen)-1]
+		}
+	}
+	walk(n, best)
+
+	solution.AllSolutionsTime = float64(time.Since(startAll).Microseconds()) / 1000
+	return solution, err
+}
+
+// shiftOr returns set | set<<shift, truncated to bits [0, limit].
+func shiftOr(set []uint64, shift, limit int) []uint64 {
+	out := make([]uint64, len(set))
+	ws, bs := shift/64, uint(shift%64)
+	for k := range out {
+		v := set[k]
+		if k >= ws {
+			v |= set[k-ws] << bs
+			if bs != 0 && k > ws {
+				v |= set[k-ws-1] >> (64 - bs)
+			}
+		}
+		out[k] = v
+	}
+	out[len(out)-1] &= lowMask(limit%64 + 1)
+	return out
+}
+
+// highestBit returns the index of the highest set bit, or -1 if none is.
+func highestBit(set []uint64) int {
+	for k := len(set) - 1; k >= 0; k-- {
+		if set[k] != 0 {
+			return k*64 + 63 - bits.LeadingZeros64(set[k])
+		}
+	}
+	return -1
+}
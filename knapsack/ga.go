@@ -0,0 +1,468 @@
+package knapsack
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/ga"
+)
+
+type Chromosome struct {
+	Genes   Genome
+	Fitness int
+	Weight  int
+}
+
+type GAConfig struct {
+	PopulationSize int
+	// Selection chooses parents; nil means a tournament of three.
+	Selection ga.Selection
+	// Crossover recombines parents; nil means OnePoint.
+	Crossover Crossover
+	// Replacement forms the next generation; nil means ga.Generational.
+	Replacement ga.Replacement
+	// Constraint scores subsets and handles overweight ones; nil means
+	// Distance, or Reject for ValueGA.
+	Constraint       Constraint
+	MutationRate     float64
+	CrossoverRate    float64
+	MaxGenerations   int
+	MaxNoImprovement int
+	// Migration splits the population of GeneticAlgorithm and ValueGA
+	// into islands when enabled.
+	Migration ga.Migration
+	// Seed initializes the random source of the run, so runs with the
+	// same items, problem and GAConfig are identical.
+	Seed int64
+	// Workers is the number of goroutines that breed and evaluate
+	// offspring; zero means one. The random sources belong to fixed blocks
+	// of offspring, so the result does not depend on Workers.
+	Workers int
+}
+
+type GAResult struct {
+	VectorID          int
+	ProblemID         int
+	TargetWeight      int
+	AchievedWeight    int
+	Fitness           int
+	Generations       int
+	Evaluations       int
+	GeneUpdates       int
+	DurationMs        float64
+	TerminationReason TerminationReason
+	Selection         string
+	Crossover         string
+	Replacement       string
+	Constraint        string
+	Migration         string
+	Seed              int64
+	BestSolution      []int
+}
+
+// DefaultGAConfig returns the parameters used in Labwork1.
+func DefaultGAConfig() GAConfig {
+	return GAConfig{
+		PopulationSize:   1000,
+		Selection:        ga.Tournament{Size: 3},
+		Crossover:        OnePoint{},
+		Replacement:      ga.Generational{},
+		Constraint:       Distance{},
+		MutationRate:     0.05,
+		CrossoverRate:    0.7,
+		MaxGenerations:   100,
+		MaxNoImprovement: 2,
+	}
+}
+
+// GeneticAlgorithm searches for a subset of items whose weight is as close
+// to problem.Target as possible. A positive problem.BruteTimeMs limits the
+// run to twice the brute-force time. If ctx is cancelled the best solution
+// found so far is returned with the Cancelled reason. With
+// config.Migration enabled the population is split into islands, see
+// IslandGA.
+func GeneticAlgorithm(ctx context.Context, items []Item, problem Problem, config GAConfig) (Chromosome, RunReport) {
+	return evolve(ctx, subsetScorers(items, problem, config), len(items), problem.BruteTimeMs, config)
+}
+
+// evolve runs the GA on chromosomes of n genes scored by the scorers
+// newScorer returns, on islands if config.Migration is enabled.
+func evolve[C any](ctx context.Context, newScorer func() scorer[C], n int, bruteTimeMs float64, config GAConfig) (C, RunReport) {
+	if config.Migration.Enabled() {
+		return islandGA(ctx, newScorer, n, bruteTimeMs, config)
+	}
+	s := newGARun(ctx, rand.New(rand.NewSource(config.Seed)), newScorer(), n, bruteTimeMs, config)
+	for !s.step() {
+	}
+	return s.finish()
+}
+
+// scorer evaluates the chromosomes of one problem type for gaRun and
+// holds its constraint, which may adapt during a run, so every run has a
+// scorer of its own.
+type scorer[C any] interface {
+	// evaluate scores genes from scratch. It returns the number of genes
+	// visited, including those changed by a repair.
+	evaluate(genes Genome) (C, int)
+	// derive scores genes as a variant of the scored parent. It returns
+	// the number of genes in which they differ or that a repair changed.
+	derive(parent C, genes Genome) (C, int)
+	genes(c C) Genome
+	// score is the selection score of c; higher is better.
+	score(c C) float64
+	// solved reports whether c is optimal, which stops the run.
+	solved(c C) bool
+	// adapt updates an adaptive constraint from the population and
+	// reports whether it changed, in which case fitness must be rescored.
+	adapt(population []C) bool
+	// rescore recomputes the fitness of c under the current constraint.
+	rescore(c C) C
+	stats(generation int, population []C, best C) GenerationStats
+	// betterFitness reports whether fitness a in GenerationStats beats b.
+	betterFitness(a, b int) bool
+}
+
+// gaRun is the state of a single GA population between generations.
+type gaRun[C any] struct {
+	ctx         context.Context
+	rng         *rand.Rand
+	config      GAConfig
+	bruteTimeMs float64
+	scorer      scorer[C]
+	selection   ga.Selection
+	crossover   Crossover
+	replacement ga.Replacement
+
+	startTime          time.Time
+	report             RunReport
+	population         []C
+	best               C
+	noImprovementCount int
+
+	lambda          int
+	scores          []float64
+	offspringScores []float64
+	sources         []*rand.Rand
+	updates         []int
+}
+
+// newGARun draws and scores an initial population of chromosomes of n
+// genes that select every gene with probability 0.35.
+func newGARun[C any](ctx context.Context, rng *rand.Rand, sc scorer[C], n int, bruteTimeMs float64, config GAConfig) *gaRun[C] {
+	s := &gaRun[C]{
+		ctx:         ctx,
+		rng:         rng,
+		config:      config,
+		bruteTimeMs: bruteTimeMs,
+		scorer:      sc,
+		selection:   config.Selection,
+		crossover:   config.Crossover,
+		replacement: config.Replacement,
+		startTime:   time.Now(),
+		report:      RunReport{Reason: MaxGenerations},
+	}
+	if s.selection == nil {
+		s.selection = ga.Tournament{Size: 3}
+	}
+	if s.crossover == nil {
+		s.crossover = OnePoint{}
+	}
+	if s.replacement == nil {
+		s.replacement = ga.Generational{}
+	}
+
+	genomes := make([]Genome, config.PopulationSize)
+	for i := range genomes {
+		genomes[i] = NewGenome(n)
+		for j := 0; j < n; j++ {
+			genomes[i].Set(j, rng.Float32() < 0.35)
+		}
+	}
+	s.population = make([]C, len(genomes))
+	for i, genes := range genomes {
+		var updates int
+		s.population[i], updates = sc.evaluate(genes)
+		s.report.GeneUpdates += updates
+	}
+	s.report.Evaluations += len(s.population)
+	s.best = s.bestOf(s.population)
+	s.lambda = s.replacement.Offspring(config.PopulationSize)
+	s.scores = make([]float64, config.PopulationSize)
+	s.offspringScores = make([]float64, s.lambda)
+	s.sources = blockSources(rng, (s.lambda+1)/2)
+	s.updates = make([]int, max(config.Workers, 1))
+	return s
+}
+
+// bestOf returns the first chromosome with the highest score.
+func (s *gaRun[C]) bestOf(population []C) C {
+	best := population[0]
+	for _, c := range population[1:] {
+		if s.scorer.score(c) > s.scorer.score(best) {
+			best = c
+		}
+	}
+	return best
+}
+
+// step breeds one generation and reports whether the run has stopped,
+// setting the termination reason when it has.
+func (s *gaRun[C]) step() bool {
+	config, sc := s.config, s.scorer
+	if s.report.Generations >= config.MaxGenerations {
+		s.report.Reason = MaxGenerations
+		return true
+	}
+	if s.ctx.Err() != nil {
+		s.report.Reason = Cancelled
+		return true
+	}
+	s.report.Generations++
+
+	if sc.adapt(s.population) {
+		for i, c := range s.population {
+			s.population[i] = sc.rescore(c)
+		}
+		s.best = sc.rescore(s.best)
+	}
+
+	for i, c := range s.population {
+		s.scores[i] = sc.score(c)
+	}
+	parents := s.selection.Select(s.rng, s.scores, (s.lambda+1)/2*2)
+
+	offspring := make([]C, len(parents))
+	forEachBlock(len(parents)/2, s.sources, config.Workers, func(w int, r *rand.Rand, from, to int) {
+		for k := 2 * from; k < 2*to; k += 2 {
+			parent1, parent2 := s.population[parents[k]], s.population[parents[k+1]]
+
+			// Mutation copies on write, so sharing the parents' genomes is safe.
+			genes1, genes2 := sc.genes(parent1), sc.genes(parent2)
+			if r.Float64() < config.CrossoverRate {
+				genes1, genes2 = s.crossover.Cross(r, genes1, genes2)
+			}
+			genes1 = mutateGenes(r, genes1, config.MutationRate)
+			genes2 = mutateGenes(r, genes2, config.MutationRate)
+
+			var updates1, updates2 int
+			offspring[k], updates1 = sc.derive(parent1, genes1)
+			offspring[k+1], updates2 = sc.derive(parent2, genes2)
+			s.updates[w] += updates1 + updates2
+		}
+	})
+	s.report.Evaluations += len(parents)
+	for w, u := range s.updates {
+		s.report.GeneUpdates += u
+		s.updates[w] = 0
+	}
+	offspring = offspring[:s.lambda]
+
+	for i, c := range offspring {
+		s.offspringScores[i] = sc.score(c)
+	}
+	newPopulation := make([]C, config.PopulationSize)
+	for i, idx := range s.replacement.Survivors(s.rng, s.scores, s.offspringScores) {
+		if idx < config.PopulationSize {
+			newPopulation[i] = s.population[idx]
+		} else {
+			newPopulation[i] = offspring[idx-config.PopulationSize]
+		}
+	}
+	s.population = newPopulation
+	currentBest := s.bestOf(s.population)
+	s.report.History = append(s.report.History, sc.stats(s.report.Generations, s.population, currentBest))
+
+	if sc.solved(currentBest) {
+		s.best = currentBest
+		s.report.Reason = ZeroFitness
+		return true
+	}
+
+	if sc.score(currentBest) <= sc.score(s.best) {
+		s.noImprovementCount++
+		if s.noImprovementCount >= config.MaxNoImprovement {
+			s.report.Reason = NoImprovement
+			return true
+		}
+	} else {
+		s.best = currentBest
+		s.noImprovementCount = 0
+	}
+
+	elapsed := time.Since(s.startTime).Seconds() * 1000
+	if s.bruteTimeMs > 0 && elapsed >= 2*s.bruteTimeMs {
+		s.report.Reason = TimeExceeded
+		return true
+	}
+	return false
+}
+
+// finish returns the best solution and the report of a stopped run.
+func (s *gaRun[C]) finish() (C, RunReport) {
+	s.report.WallTime = time.Since(s.startTime)
+	return s.best, s.report
+}
+
+// subsetScorer scores subset-sum chromosomes under a Constraint.
+type subsetScorer struct {
+	items      []Item
+	target     int
+	constraint Constraint
+}
+
+// subsetScorers returns the scorers of GeneticAlgorithm; a nil constraint
+// means Distance.
+func subsetScorers(items []Item, problem Problem, config GAConfig) func() scorer[Chromosome] {
+	constraint := config.Constraint
+	if constraint == nil {
+		constraint = Distance{}
+	}
+	return func() scorer[Chromosome] {
+		return &subsetScorer{items: items, target: problem.Target, constraint: constraint}
+	}
+}
+
+// repair repairs c if the constraint is a Repairer and sets its fitness.
+// It returns the number of genes changed by the repair.
+func (s *subsetScorer) repair(c Chromosome) (Chromosome, int) {
+	changed := 0
+	if repairer, ok := s.constraint.(Repairer); ok {
+		c, changed = repairer.Repair(c, s.items, s.target)
+	}
+	return s.rescore(c), changed
+}
+
+func (s *subsetScorer) evaluate(genes Genome) (Chromosome, int) {
+	c, changed := s.repair(CalculateFitness(Chromosome{Genes: genes}, s.items, s.target))
+	return c, genes.Count() + changed
+}
+
+func (s *subsetScorer) derive(parent Chromosome, genes Genome) (Chromosome, int) {
+	child, derived := Derive(parent, genes, s.items, s.target)
+	c, repaired := s.repair(child)
+	return c, derived + repaired
+}
+
+func (s *subsetScorer) genes(c Chromosome) Genome { return c.Genes }
+
+func (s *subsetScorer) score(c Chromosome) float64 { return -float64(c.Fitness) }
+
+func (s *subsetScorer) solved(c Chromosome) bool { return c.Fitness == 0 }
+
+func (s *subsetScorer) adapt(population []Chromosome) bool {
+	adaptive, ok := s.constraint.(AdaptiveConstraint)
+	if ok {
+		s.constraint = adaptive.Adapt(feasibleRate(population, s.target))
+	}
+	return ok
+}
+
+func (s *subsetScorer) rescore(c Chromosome) Chromosome {
+	c.Fitness = s.constraint.Fitness(c.Weight, s.target)
+	return c
+}
+
+func (s *subsetScorer) stats(generation int, population []Chromosome, best Chromosome) GenerationStats {
+	return generationStats(generation, population, best)
+}
+
+func (s *subsetScorer) betterFitness(a, b int) bool { return a < b }
+
+func CalculateFitness(c Chromosome, items []Item, target int) Chromosome {
+	totalWeight := 0
+	c.Genes.ForEachSet(func(i int) { totalWeight += items[i].Weight })
+
+	c.Weight = totalWeight
+	c.Fitness = distance(totalWeight, target)
+	return c
+}
+
+// Derive evaluates genes as a variant of the evaluated parent. Only the
+// genes in which they differ are visited; their number is returned along
+// with the child.
+func Derive(parent Chromosome, genes Genome, items []Item, target int) (Chromosome, int) {
+	child := Chromosome{Genes: genes, Weight: parent.Weight}
+	changed := 0
+	genes.forEachDiff(parent.Genes, func(i int) {
+		if genes.Get(i) {
+			child.Weight += items[i].Weight
+		} else {
+			child.Weight -= items[i].Weight
+		}
+		changed++
+	})
+	child.Fitness = distance(child.Weight, target)
+	return child, changed
+}
+
+func distance(weight, target int) int {
+	return int(math.Abs(float64(target - weight)))
+}
+
+// InitializePopulation returns populationSize evaluated chromosomes that
+// select every item with probability 0.35, drawn from r.
+func InitializePopulation(r *rand.Rand, itemCount, populationSize int, items []Item, target int) []Chromosome {
+	population := make([]Chromosome, populationSize)
+	for i := range population {
+		genes := NewGenome(itemCount)
+		for j := 0; j < itemCount; j++ {
+			genes.Set(j, r.Float32() < 0.35)
+		}
+		population[i] = CalculateFitness(Chromosome{Genes: genes}, items, target)
+	}
+	return population
+}
+
+// Mutate flips every gene with probability mutationRate and updates the
+// weight and fitness of c by the weight of each flipped item, so c must
+// already be evaluated. The genome of c is never modified.
+func Mutate(r *rand.Rand, c Chromosome, mutationRate float64, items []Item, target int) Chromosome {
+	c, _ = Derive(c, mutateGenes(r, c.Genes, mutationRate), items, target)
+	return c
+}
+
+// mutateGenes flips every gene of g with probability mutationRate, drawing
+// from r. The first flip switches the result to a private copy, so g is
+// returned as is when nothing changes.
+func mutateGenes(r *rand.Rand, g Genome, mutationRate float64) Genome {
+	owned := false
+	forEachBernoulli(r, g.Len(), mutationRate, func(i int) {
+		if !owned {
+			g = g.Clone()
+			owned = true
+		}
+		g.Flip(i)
+	})
+	return g
+}
+
+func FindBest(population []Chromosome) Chromosome {
+	best := population[0]
+	for _, c := range population {
+		if c.Fitness < best.Fitness {
+			best = c
+		}
+	}
+	return best
+}
+
+// SolutionIndices returns the sorted item indices selected by c.
+func SolutionIndices(c Chromosome, items []Item) []int {
+	indices := make([]int, 0, c.Genes.Count())
+	c.Genes.ForEachSet(func(i int) { indices = append(indices, items[i].Index) })
+	sort.Ints(indices)
+	return indices
+}
+
+// FormatSolution formats indices as a space-separated list.
+func FormatSolution(indices []int) string {
+	if len(indices) == 0 {
+		return ""
+	}
+	str := fmt.Sprintf("%v", indices)
+	return str[1 : len(str)-1]
+}
@@ -0,0 +1,36 @@
+package knapsack
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMutationKeepsParent(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	items, target := randomInstance(r, 130, 1000)
+	for _, rate := range []float64{0, 0.01, 0.5, 1} {
+		for trial := 0; trial < 20; trial++ {
+			parent := CalculateFitness(Chromosome{Genes: randomGenome(r, len(items))}, items, target)
+			want := parent.Genes.Clone()
+
+			genes := mutateGenes(r, parent.Genes, rate)
+			if !parent.Genes.Equal(want) {
+				t.Fatalf("mutateGenes with rate %g modified the parent", rate)
+			}
+			if !genes.Equal(want) {
+				genes.Flip(0)
+				if !parent.Genes.Equal(want) {
+					t.Fatalf("mutateGenes with rate %g returned a mutant sharing the parent's genes", rate)
+				}
+			}
+
+			child := Mutate(r, parent, rate, items, target)
+			if !parent.Genes.Equal(want) {
+				t.Fatalf("Mutate with rate %g modified the parent", rate)
+			}
+			if got := CalculateFitness(Chromosome{Genes: child.Genes}, items, target); got.Weight != child.Weight {
+				t.Fatalf("Mutate with rate %g: weight %d, want %d", rate, child.Weight, got.Weight)
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package knapsack
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// GenerateKnapsackVector returns length weights in [1, maxValue] drawn
+// from r.
+func GenerateKnapsackVector(r *rand.Rand, length, maxValue int) []int {
+	vector := make([]int, length)
+	for i := 0; i < length; i++ {
+		vector[i] = r.Intn(maxValue) + 1
+	}
+	return vector
+}
+
+func vectorKey(vec []int) string {
+	key := ""
+	for _, v := range vec {
+		key += fmt.Sprintf("%d,", v)
+	}
+	return key
+}
+
+// GenerateUniqueVectors returns count distinct vectors produced by
+// GenerateKnapsackVector.
+func GenerateUniqueVectors(r *rand.Rand, count, length, maxValue int) [][]int {
+	vectorsMap := make(map[string]bool)
+	vectors := [][]int{}
+
+	for len(vectors) < count {
+		vec := GenerateKnapsackVector(r, length, maxValue)
+		key := vectorKey(vec)
+		if !vectorsMap[key] {
+			vectorsMap[key] = true
+			vectors = append(vectors, vec)
+		}
+	}
+	return vectors
+}
+
+// GenerateTask picks between minItems and maxItems items at random from r
+// and returns their total weight together with the chosen indices.
+func GenerateTask(r *rand.Rand, weights []int, minItems, maxItems int) (int, []int) {
+	n := len(weights)
+
+	numSelectedItems := r.Intn(maxItems-minItems+1) + minItems
+
+	selectedItems := r.Perm(n)[:numSelectedItems]
+
+	var totalWeight int
+	for _, idx := range selectedItems {
+		totalWeight += weights[idx]
+	}
+
+	return totalWeight, selectedItems
+}
+
+// GenerateValuedItems returns length items with values in [1, maxValue]
+// and dims weights in [1, maxWeight], drawn from r.
+func GenerateValuedItems(r *rand.Rand, length, dims, maxWeight, maxValue int) []ValuedItem {
+	items := make([]ValuedItem, length)
+	for i := range items {
+		items[i] = ValuedItem{
+			Value:   r.Intn(maxValue) + 1,
+			Weights: GenerateKnapsackVector(r, dims, maxWeight),
+			Index:   i,
+		}
+	}
+	return items
+}
+
+// GenerateCapacities returns, for every weight dimension, ratio times the
+// total weight of items in that dimension.
+func GenerateCapacities(items []ValuedItem, ratio float64) []int {
+	capacities := make([]int, len(items[0].Weights))
+	for _, item := range items {
+		for d, w := range item.Weights {
+			capacities[d] += w
+		}
+	}
+	for d := range capacities {
+		capacities[d] = int(ratio * float64(capacities[d]))
+	}
+	return capacities
+}
@@ -0,0 +1,165 @@
+package knapsack
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+)
+
+// Genome is a fixed-length bit string packed into uint64 words, one bit
+// per item.
+//
+// Copying a Genome value shares its storage. The GA treats genomes that
+// belong to a Chromosome as immutable: crossover and mutation always
+// return new genomes, so parents are never modified by reproduction. Use
+// Clone before calling Set or Flip on a genome you do not own.
+type Genome struct {
+	words []uint64
+	n     int
+}
+
+func NewGenome(n int) Genome {
+	return Genome{words: make([]uint64, (n+63)/64), n: n}
+}
+
+// GenomeFromBools packs genes into a new Genome.
+func GenomeFromBools(genes []bool) Genome {
+	g := NewGenome(len(genes))
+	for i, gene := range genes {
+		if gene {
+			g.Set(i, true)
+		}
+	}
+	return g
+}
+
+// Len returns the number of bits in g.
+func (g Genome) Len() int { return g.n }
+
+func (g Genome) Get(i int) bool {
+	return g.words[i/64]&(1<<uint(i%64)) != 0
+}
+
+func (g Genome) Set(i int, v bool) {
+	if v {
+		g.words[i/64] |= 1 << uint(i%64)
+	} else {
+		g.words[i/64] &^= 1 << uint(i%64)
+	}
+}
+
+func (g Genome) Flip(i int) {
+	g.words[i/64] ^= 1 << uint(i%64)
+}
+
+// Clone returns a copy of g that does not share storage with it.
+func (g Genome) Clone() Genome {
+	return Genome{words: append([]uint64(nil), g.words...), n: g.n}
+}
+
+func (g Genome) Equal(other Genome) bool {
+	if g.n != other.n {
+		return false
+	}
+	for i, w := range g.words {
+		if w != other.words[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of set bits.
+func (g Genome) Count() int {
+	count := 0
+	for _, w := range g.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// Bools unpacks g into a new slice.
+func (g Genome) Bools() []bool {
+	genes := make([]bool, g.n)
+	for i := range genes {
+		genes[i] = g.Get(i)
+	}
+	return genes
+}
+
+// ForEachSet calls fn with the index of every set bit in increasing order.
+func (g Genome) ForEachSet(fn func(i int)) {
+	for wi, w := range g.words {
+		for w != 0 {
+			fn(wi*64 + bits.TrailingZeros64(w))
+			w &= w - 1
+		}
+	}
+}
+
+// lowMask returns a word with the lowest k bits set, 0 <= k <= 64.
+func lowMask(k int) uint64 {
+	if k >= 64 {
+		return ^uint64(0)
+	}
+	return 1<<uint(k) - 1
+}
+
+// swapRange exchanges bits [from, to) between a and b word by word.
+func swapRange(a, b Genome, from, to int) {
+	for from < to {
+		wi, bit := from/64, from%64
+		n := min(64-bit, to-from)
+		m := lowMask(n) << uint(bit)
+		d := (a.words[wi] ^ b.words[wi]) & m
+		a.words[wi] ^= d
+		b.words[wi] ^= d
+		from += n
+	}
+}
+
+// swapMask exchanges the bits selected by mask between a and b.
+func swapMask(a, b Genome, mask []uint64) {
+	for wi, m := range mask {
+		d := (a.words[wi] ^ b.words[wi]) & m
+		a.words[wi] ^= d
+		b.words[wi] ^= d
+	}
+}
+
+// forEachBernoulli calls fn for every index in [0, n) that succeeds in an
+// independent Bernoulli(p) trial drawn from r. Instead of drawing n numbers it samples
+// the geometric gaps between successes, so the cost is proportional to the
+// number of successes.
+func forEachBernoulli(r *rand.Rand, n int, p float64, fn func(i int)) {
+	if p <= 0 {
+		return
+	}
+	if p >= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+	logq := math.Log1p(-p)
+	for i := -1; ; {
+		skip := math.Floor(math.Log(1-r.Float64()) / logq)
+		if skip >= float64(n-i-1) {
+			return
+		}
+		i += int(skip) + 1
+		fn(i)
+	}
+}
+
+// forEachDiff calls fn with the index of every bit in which g and other
+// differ. Both genomes must have the same length.
+func (g Genome) forEachDiff(other Genome, fn func(i int)) {
+	for wi, w := range g.words {
+		d := w ^ other.words[wi]
+		for d != 0 {
+			fn(wi*64 + bits.TrailingZeros64(d))
+			d &= d - 1
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package knapsack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// The bool functions below are the []bool representation Genome replaced,
+// kept as a baseline for the benchmarks.
+
+func boolWeight(genes []bool, items []Item) int {
+	w := 0
+	for i, g := range genes {
+		if g {
+			w += items[i].Weight
+		}
+	}
+	return w
+}
+
+func boolOnePoint(r *rand.Rand, p1, p2 []bool) ([]bool, []bool) {
+	point := r.Intn(len(p1))
+	c1 := append([]bool(nil), p1...)
+	c2 := append([]bool(nil), p2...)
+	for i := point; i < len(p1); i++ {
+		c1[i], c2[i] = c2[i], c1[i]
+	}
+	return c1, c2
+}
+
+func boolMutate(r *rand.Rand, genes []bool, rate float64) []bool {
+	genes = append([]bool(nil), genes...)
+	for i := range genes {
+		if r.Float64() < rate {
+			genes[i] = !genes[i]
+		}
+	}
+	return genes
+}
+
+func randomBools(r *rand.Rand, n int) []bool {
+	genes := make([]bool, n)
+	for i := range genes {
+		genes[i] = r.Float32() < 0.35
+	}
+	return genes
+}
+
+const benchMutationRate = 0.05
+
+// benchmarkGenomes runs fn for every genome length with a fixed random
+// source, items and two random parents in both representations.
+func benchmarkGenomes(b *testing.B, fn func(b *testing.B, r *rand.Rand, items []Item, b1, b2 []bool, g1, g2 Genome)) {
+	for _, n := range []int{24, 256, 4096} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+			items := make([]Item, n)
+			for i := range items {
+				items[i] = Item{Weight: r.Intn(1 << 16), Index: i + 1}
+			}
+			b1, b2 := randomBools(r, n), randomBools(r, n)
+			b.ReportAllocs()
+			fn(b, r, items, b1, b2, GenomeFromBools(b1), GenomeFromBools(b2))
+		})
+	}
+}
+
+func BenchmarkEvalBool(b *testing.B) {
+	benchmarkGenomes(b, func(b *testing.B, r *rand.Rand, items []Item, b1, b2 []bool, g1, g2 Genome) {
+		for i := 0; i < b.N; i++ {
+			boolWeight(b1, items)
+		}
+	})
+}
+
+func BenchmarkEvalPacked(b *testing.B) {
+	benchmarkGenomes(b, func(b *testing.B, r *rand.Rand, items []Item, b1, b2 []bool, g1, g2 Genome) {
+		c := Chromosome{Genes: g1}
+		for i := 0; i < b.N; i++ {
+			CalculateFitness(c, items, 0)
+		}
+	})
+}
+
+func BenchmarkCrossBool(b *testing.B) {
+	benchmarkGenomes(b, func(b *testing.B, r *rand.Rand, items []Item, b1, b2 []bool, g1, g2 Genome) {
+		for i := 0; i < b.N; i++ {
+			boolOnePoint(r, b1, b2)
+		}
+	})
+}
+
+func BenchmarkCrossPacked(b *testing.B) {
+	benchmarkGenomes(b, func(b *testing.B, r *rand.Rand, items []Item, b1, b2 []bool, g1, g2 Genome) {
+		for i := 0; i < b.N; i++ {
+			OnePoint{}.Cross(r, g1, g2)
+		}
+	})
+}
+
+func BenchmarkMutateBool(b *testing.B) {
+	benchmarkGenomes(b, func(b *testing.B, r *rand.Rand, items []Item, b1, b2 []bool, g1, g2 Genome) {
+		for i := 0; i < b.N; i++ {
+			boolWeight(boolMutate(r, b1, benchMutationRate), items)
+		}
+	})
+}
+
+func BenchmarkMutatePacked(b *testing.B) {
+	benchmarkGenomes(b, func(b *testing.B, r *rand.Rand, items []Item, b1, b2 []bool, g1, g2 Genome) {
+		c := CalculateFitness(Chromosome{Genes: g1}, items, 0)
+		for i := 0; i < b.N; i++ {
+			Mutate(r, c, benchMutationRate, items, 0)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package knapsack
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// GrayCodeBruteForce solves the same problem as BruteForce, but walks the
+// masks in Gray-code order, so every step adds or removes a single item
+// and the running weight is updated in O(1) instead of being summed
+// again. Index lists are only built for the first improvement; the masks
+// that tie with the best weight are kept and turned into combinations at
+// the end, in the same order as BruteForce.
+func GrayCodeBruteForce(ctx context.Context, items []Item, target int) (Solution, error) {
+	startAll := time.Now()
+	maxWeight := 0
+	var masks []uint64
+	var firstSolutionTime time.Time
+	var firstCombination []int
+	firstWeight := 0
+	found := false
+
+	err := walkSubsets(ctx, items, 0, len(items), func(weight int, mask uint64) bool {
+		if weight > target || weight < maxWeight {
+			return true
+		}
+		if weight > maxWeight {
+			maxWeight = weight
+			masks = append(masks[:0], mask)
+			if !found {
+				firstSolutionTime = time.Now()
+				firstCombination = maskIndices(items, mask)
+				firstWeight = maxWeight
+				found = true
+			}
+		} else {
+			masks = append(masks, mask)
+		}
+		return true
+	})
+
+	sort.Slice(masks, func(a, b int) bool { return masks[a] < masks[b] })
+	solutions := make([][]int, 0, len(masks))
+	for _, mask := range masks {
+		solutions = append(solutions, maskIndices(items, mask))
+	}
+
+	allTime := time.Since(startAll)
+	var firstTime time.Duration
+	if found {
+		firstTime = firstSolutionTime.Sub(startAll)
+	}
+
+	return Solution{
+		AchievedWeight:    maxWeight,
+		Combinations:      solutions,
+		FirstSolutionTime: float64(firstTime.Microseconds()) / 1000,
+		AllSolutionsTime:  float64(allTime.Microseconds()) / 1000,
+		FirstCombination:  firstCombination,
+		FirstWeight:       firstWeight,
+	}, err
+}
@@ -0,0 +1,32 @@
+package knapsack
+
+import (
+	"context"
+	"testing"
+)
+
+// benchWeights is the first item vector of Labwork1 and benchTarget the
+// target of its first problem.
+var benchWeights = []int{
+	141560, 20627, 34114, 76731, 91200, 104873, 51375, 69486, 71286, 20668, 121691, 128217,
+	74573, 136580, 116150, 103578, 124420, 72388, 135461, 76377, 123951, 75741, 118320, 102246,
+}
+
+const benchTarget = 521524
+
+func benchmarkSolver(b *testing.B, solve ExactSolver) {
+	items := make([]Item, len(benchWeights))
+	for i, w := range benchWeights {
+		items[i] = Item{Weight: w, Index: i + 1}
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := solve(context.Background(), items, benchTarget); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBruteForce(b *testing.B) { benchmarkSolver(b, BruteForce) }
+
+func BenchmarkGrayCodeBruteForce(b *testing.B) { benchmarkSolver(b, GrayCodeBruteForce) }
@@ -0,0 +1,131 @@
+package knapsack
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/ga"
+)
+
+// gaIsland adapts a GA run to ga.Island.
+type gaIsland[C any] struct {
+	*gaRun[C]
+}
+
+func (s gaIsland[C]) Evolve(generations int) bool {
+	for k := 0; k < generations; k++ {
+		if s.step() {
+			return true
+		}
+	}
+	return false
+}
+
+func (s gaIsland[C]) Population() ([]C, []float64) {
+	scores := make([]float64, len(s.population))
+	for i, c := range s.population {
+		scores[i] = s.scorer.score(c)
+	}
+	return s.population, scores
+}
+
+// Replace rescores c under the constraint of the island, which may differ
+// from that of its origin when the constraint is adaptive. Genomes are
+// copied on write, so c is shared as is.
+func (s gaIsland[C]) Replace(i int, c C) {
+	s.population[i] = s.scorer.rescore(c)
+}
+
+// IslandGA is GeneticAlgorithm with the population split evenly among
+// config.Migration.Islands islands that evolve concurrently and exchange
+// their best chromosomes; their random sources are seeded in turn from
+// config.Seed. The generation and stagnation limits apply to every island
+// on its own, and the run ends after the epoch in which an island hits the
+// target. The report sums the evaluations of all islands, its history
+// holds the best and mean fitness over all islands, and its reason is that
+// of the island with the best solution. The fitness of the solution is
+// that under config.Constraint, even if the islands adapted it.
+func IslandGA(ctx context.Context, items []Item, problem Problem, config GAConfig) (Chromosome, RunReport) {
+	return islandGA(ctx, subsetScorers(items, problem, config), len(items), problem.BruteTimeMs, config)
+}
+
+// islandGA is IslandGA for chromosomes of n genes scored by the scorers
+// newScorer returns.
+func islandGA[C any](ctx context.Context, newScorer func() scorer[C], n int, bruteTimeMs float64, config GAConfig) (C, RunReport) {
+	startTime := time.Now()
+	m := config.Migration
+	sub := config
+	sub.Migration = ga.Migration{}
+	sub.PopulationSize = max(config.PopulationSize/m.Islands, 2)
+
+	rng := rand.New(rand.NewSource(config.Seed))
+	runs := make([]*gaRun[C], m.Islands)
+	islands := make([]ga.Island[C], m.Islands)
+	for i := range runs {
+		runs[i] = newGARun(ctx, rand.New(rand.NewSource(rng.Int63())), newScorer(), n, bruteTimeMs, sub)
+		islands[i] = gaIsland[C]{runs[i]}
+	}
+	// The run stops once all islands have finished the epoch in which one
+	// of them hit the target, so that it does not depend on goroutine
+	// scheduling.
+	solved := func() bool {
+		for _, s := range runs {
+			if s.report.Reason == ZeroFitness {
+				return true
+			}
+		}
+		return false
+	}
+	ga.EvolveIslands(rng, islands, m, solved)
+
+	// Adaptive constraints give every island its own scale, so the bests
+	// are compared under the configured constraint.
+	judge := newScorer()
+	bestRun := runs[0]
+	best := judge.rescore(bestRun.best)
+	for _, s := range runs[1:] {
+		if c := judge.rescore(s.best); judge.score(c) > judge.score(best) {
+			bestRun, best = s, c
+		}
+	}
+	report := RunReport{Reason: bestRun.report.Reason}
+	if ctx.Err() != nil {
+		report.Reason = Cancelled
+	}
+	for _, s := range runs {
+		report.Generations = max(report.Generations, s.report.Generations)
+		report.Evaluations += s.report.Evaluations
+		report.GeneUpdates += s.report.GeneUpdates
+	}
+	report.History = mergeHistories(runs)
+	report.WallTime = time.Since(startTime)
+	return best, report
+}
+
+// mergeHistories combines the histories of the islands generation by
+// generation: the best fitness is the best of the islands that reached the
+// generation and the mean is the mean over their populations.
+func mergeHistories[C any](runs []*gaRun[C]) []GenerationStats {
+	better := runs[0].scorer.betterFitness
+	var merged []GenerationStats
+	counts := []int{}
+	for _, s := range runs {
+		for g, stats := range s.report.History {
+			if g == len(merged) {
+				merged = append(merged, stats)
+				counts = append(counts, 1)
+				continue
+			}
+			if better(stats.BestFitness, merged[g].BestFitness) {
+				merged[g].BestFitness, merged[g].BestWeight = stats.BestFitness, stats.BestWeight
+			}
+			merged[g].MeanFitness += stats.MeanFitness
+			counts[g]++
+		}
+	}
+	for g := range merged {
+		merged[g].MeanFitness /= float64(counts[g])
+	}
+	return merged
+}
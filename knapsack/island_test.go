@@ -0,0 +1,52 @@
+package knapsack
+
+import (
+	"context"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/ga"
+)
+
+func TestIslandGAReproducible(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(2))
+
+	items, target := randomInstance(rand.New(rand.NewSource(1)), 24, 1<<16)
+	problem := Problem{ID: 1, Target: target}
+	config := DefaultGAConfig()
+	config.PopulationSize = 400
+	config.MaxGenerations = 1000
+	config.MaxNoImprovement = 200
+	config.Migration = ga.Migration{Islands: 8, Interval: 20, Migrants: 2, Topology: ga.Ring{}}
+	config.Seed = 7
+
+	want, wantReport := GeneticAlgorithm(context.Background(), items, problem, config)
+	for run := 0; run < 20; run++ {
+		got, report := GeneticAlgorithm(context.Background(), items, problem, config)
+		if got.Weight != want.Weight || report.Reason != wantReport.Reason ||
+			report.Generations != wantReport.Generations || report.Evaluations != wantReport.Evaluations {
+			t.Fatalf("run %d: weight %d, %s after %d generations and %d evaluations, want weight %d, %s after %d and %d",
+				run, got.Weight, report.Reason, report.Generations, report.Evaluations,
+				want.Weight, wantReport.Reason, wantReport.Generations, wantReport.Evaluations)
+		}
+	}
+}
+
+func TestIslandGABestUnderConfiguredConstraint(t *testing.T) {
+	items, target := randomInstance(rand.New(rand.NewSource(3)), 40, 1<<16)
+	problem := Problem{ID: 1, Target: target}
+	config := DefaultGAConfig()
+	config.PopulationSize = 200
+	config.MaxNoImprovement = 20
+	config.Constraint = AdaptivePenalty{Coefficient: 2, Factor: 1.2}
+	config.Migration = ga.Migration{Islands: 4, Interval: 5, Migrants: 1, Topology: ga.Ring{}}
+
+	for seed := int64(1); seed <= 5; seed++ {
+		config.Seed = seed
+		best, _ := GeneticAlgorithm(context.Background(), items, problem, config)
+		if want := config.Constraint.Fitness(best.Weight, target); best.Fitness != want {
+			t.Errorf("seed %d: fitness %d, want %d under %s", seed, best.Fitness, want, config.Constraint)
+		}
+	}
+}
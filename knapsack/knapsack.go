@@ -0,0 +1,192 @@
+// Package knapsack contains the subset-sum knapsack model from Labwork1
+// and the general 0/1 knapsack with item values, together with their exact
+// and genetic solvers.
+package knapsack
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+)
+
+// ProblemsPerVector is the number of target weights generated for every
+// item vector in problems.csv.
+const ProblemsPerVector = 15
+
+type Item struct {
+	Weight int
+	Index  int
+}
+
+type Problem struct {
+	ID          int
+	Target      int
+	Ratio       float64
+	BruteTimeMs float64
+}
+
+// ReadItems reads one item vector per CSV row.
+func ReadItems(filename string) ([][]Item, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	itemsList := make([][]Item, len(records))
+	for i, record := range records {
+		items := make([]Item, len(record))
+		for j, value := range record {
+			weight, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, err
+			}
+			items[j] = Item{Weight: weight, Index: j}
+		}
+		itemsList[i] = items
+	}
+
+	return itemsList, nil
+}
+
+// ReadVectors reads the raw weight vectors, skipping values that are not
+// integers.
+func ReadVectors(path string) ([][]int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var vectors [][]int
+	for _, record := range records {
+		var vec []int
+		for _, val := range record {
+			num, err := strconv.Atoi(val)
+			if err != nil {
+				log.Println("Ошибка при преобразовании значения в целое число:", err)
+				continue
+			}
+			vec = append(vec, num)
+		}
+		vectors = append(vectors, vec)
+	}
+	return vectors, nil
+}
+
+// ReadProblems reads problems.csv and groups its rows by item vector.
+func ReadProblems(filename string) ([][]Problem, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	problemsList := make([][]Problem, 0)
+	currentGroup := make([]Problem, 0, ProblemsPerVector)
+
+	for i, record := range records {
+		if len(record) != 3 {
+			return nil, fmt.Errorf("invalid number of fields in row %d", i+1)
+		}
+
+		id, err := strconv.Atoi(record[0])
+		if err != nil {
+			return nil, err
+		}
+
+		target, err := strconv.Atoi(record[1])
+		if err != nil {
+			return nil, err
+		}
+
+		ratio, err := strconv.ParseFloat(record[2], 64)
+		if err != nil {
+			return nil, err
+		}
+
+		problem := Problem{
+			ID:     id,
+			Target: target,
+			Ratio:  ratio,
+		}
+
+		currentGroup = append(currentGroup, problem)
+		if len(currentGroup) == ProblemsPerVector {
+			problemsList = append(problemsList, currentGroup)
+			currentGroup = make([]Problem, 0, ProblemsPerVector)
+		}
+	}
+
+	if len(currentGroup) > 0 {
+		problemsList = append(problemsList, currentGroup)
+	}
+
+	return problemsList, nil
+}
+
+// ReadBruteTimes reads the AllSolutionsTime(ms) column of a brute-force
+// results file and groups it by item vector.
+func ReadBruteTimes(filename string) ([][]float64, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) > 0 {
+		records = records[1:]
+	}
+
+	bruteTimes := make([][]float64, 0)
+	currentGroup := make([]float64, 0, ProblemsPerVector)
+
+	for _, record := range records {
+		if len(record) < 7 {
+			continue
+		}
+
+		timeMs, err := strconv.ParseFloat(record[6], 64)
+		if err != nil {
+			return nil, err
+		}
+
+		currentGroup = append(currentGroup, timeMs)
+		if len(currentGroup) == ProblemsPerVector {
+			bruteTimes = append(bruteTimes, currentGroup)
+			currentGroup = make([]float64, 0, ProblemsPerVector)
+		}
+	}
+
+	if len(currentGroup) > 0 {
+		bruteTimes = append(bruteTimes, currentGroup)
+	}
+
+	return bruteTimes, nil
+}
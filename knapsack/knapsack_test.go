@@ -0,0 +1,15 @@
+package knapsack
+
+import "math/rand"
+
+// randomInstance returns n items with weights in [1, maxWeight] and the
+// weight of a random subset of them as the target.
+func randomInstance(r *rand.Rand, n, maxWeight int) ([]Item, int) {
+	weights := GenerateKnapsackVector(r, n, maxWeight)
+	items := make([]Item, n)
+	for i, w := range weights {
+		items[i] = Item{Weight: w, Index: i + 1}
+	}
+	target, _ := GenerateTask(r, weights, 1, n)
+	return items, target
+}
@@ -0,0 +1,276 @@
+package knapsack
+
+import (
+	"context"
+	"errors"
+	"math/bits"
+	"sort"
+	"time"
+)
+
+// maxMITMItems is the largest number of items MeetInTheMiddle and
+// StreamMeetInTheMiddle accept. The sums and masks of a half are kept in
+// memory, 16 bytes per subset, so 24 items per half already take 256 MiB
+// each.
+const maxMITMItems = 48
+
+// DefaultCombinationLimit is how many optimal combinations
+// StreamMeetInTheMiddle keeps when run through ExactSolvers.
+const DefaultCombinationLimit = 1000
+
+var errTooManyItems = errors.New("knapsack: too many items for meet-in-the-middle")
+
+// halfSums holds every subset sum of a range of items in ascending order
+// together with the masks that produce them.
+type halfSums struct {
+	sums  []int
+	masks []uint64
+}
+
+// subsetSums enumerates the subsets of items[from:to] in ascending order
+// of weight by merging, for each item, the sums without it and with it
+// (Horowitz and Sahni). Masks are relative to the whole item slice.
+func subsetSums(ctx context.Context, items []Item, from, to int) (halfSums, error) {
+	h := halfSums{sums: []int{0}, masks: []uint64{0}}
+	for i := from; i < to; i++ {
+		if err := ctx.Err(); err != nil {
+			return halfSums{}, err
+		}
+		w, bit := items[i].Weight, uint64(1)<<uint(i)
+		size := len(h.sums)
+		merged := halfSums{sums: make([]int, 0, 2*size), masks: make([]uint64, 0, 2*size)}
+		a, b := 0, 0
+		for a < size || b < size {
+			if b == size || (a < size && h.sums[a] <= h.sums[b]+w) {
+				merged.sums = append(merged.sums, h.sums[a])
+				merged.masks = append(merged.masks, h.masks[a])
+				a++
+			} else {
+				merged.sums = append(merged.sums, h.sums[b]+w)
+				merged.masks = append(merged.masks, h.masks[b]|bit)
+				b++
+			}
+		}
+		h = merged
+	}
+	return h, nil
+}
+
+// MeetInTheMiddle solves the same problem as BruteForce in O(2^(n/2))
+// time and memory: the subset sums of both halves are enumerated in sorted
+// order and paired with two pointers. It returns the same Solution, with
+// the combinations in the same order. FirstCombination is the first
+// optimal combination.
+func MeetInTheMiddle(ctx context.Context, items []Item, target int) (Solution, error) {
+	startAll := time.Now()
+	n := len(items)
+	if n > maxMITMItems {
+		return Solution{}, errTooManyItems
+	}
+	solution := Solution{Combinations: [][]int{}}
+
+	left, err := subsetSums(ctx, items, 0, n/2)
+	if err != nil {
+		return solution, err
+	}
+	right, err := subsetSums(ctx, items, n/2, n)
+	if err != nil {
+		return solution, err
+	}
+
+	// As the left sum grows, the largest right sum that still fits can
+	// only move down.
+	best := -1
+	j := len(right.sums) - 1
+	for _, s := range left.sums {
+		if s > target {
+			break
+		}
+		for j >= 0 && s+right.sums[j] > target {
+			j--
+		}
+		if j < 0 {
+			break
+		}
+		best = max(best, s+right.sums[j])
+	}
+	if best < 0 {
+		return solution, ctx.Err()
+	}
+	solution.AchievedWeight = best
+
+	var masks []uint64
+	j = len(right.sums) - 1
+	for i, s := range left.sums {
+		if i%cancelCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return solution, err
+			}
+		}
+		if s > best {
+			break
+		}
+		for j >= 0 && right.sums[j] > best-s {
+			j--
+		}
+		for k := j; k >= 0 && right.sums[k] == best-s; k-- {
+			masks = append(masks, left.masks[i]|right.masks[k])
+		}
+	}
+	sort.Slice(masks, func(a, b int) bool { return masks[a] < masks[b] })
+
+	for _, mask := range masks {
+		solution.Combinations = append(solution.Combinations, maskIndices(items, mask))
+	}
+	solution.FirstCombination = solution.Combinations[0]
+	solution.FirstWeight = best
+	solution.AllSolutionsTime = float64(time.Since(startAll).Microseconds()) / 1000
+	solution.FirstSolutionTime = solution.AllSolutionsTime
+	return solution, nil
+}
+
+// maskIndices returns the indices of the items selected by mask in
+// position order.
+func maskIndices(items []Item, mask uint64) []int {
+	var indices []int
+	for i := range items {
+		if mask&(1<<uint(i)) != 0 {
+			indices = append(indices, items[i].Index)
+		}
+	}
+	return indices
+}
+
+// walkSubsets visits every subset of items[from:to] in Gray-code order,
+// so that consecutive subsets differ in a single item, and stops early
+// when visit returns false. Masks are relative to the whole item slice.
+func walkSubsets(ctx context.Context, items []Item, from, to int, visit func(sum int, mask uint64) bool) error {
+	sum, mask := 0, uint64(0)
+	for step := uint64(0); step < 1<<uint(to-from); step++ {
+		if step%cancelCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		if step > 0 {
+			i := from + bits.TrailingZeros64(step)
+			bit := uint64(1) << uint(i)
+			if mask&bit != 0 {
+				sum -= items[i].Weight
+			} else {
+				sum += items[i].Weight
+			}
+			mask ^= bit
+		}
+		if !visit(sum, mask) {
+			break
+		}
+	}
+	return nil
+}
+
+// StreamMeetInTheMiddle counts every subset of items with the largest
+// weight not exceeding target while keeping at most limit of them in
+// Combinations; the others are only counted in Omitted. Only the sorted
+// subset sums of the first half are held in memory, the subsets of the
+// second half are streamed past them twice: once to find the best weight
+// and once to collect the subsets that reach it, which keeps about 48
+// items within reach. The kept combinations are the first limit in
+// increasing mask order like BruteForce; FirstCombination is the first
+// optimal combination streamed.
+func StreamMeetInTheMiddle(ctx context.Context, items []Item, target, limit int) (Solution, error) {
+	startAll := time.Now()
+	n := len(items)
+	if n > maxMITMItems {
+		return Solution{}, errTooManyItems
+	}
+	solution := Solution{Combinations: [][]int{}}
+	if target < 0 {
+		return solution, nil
+	}
+
+	left, err := subsetSums(ctx, items, 0, n/2)
+	if err != nil {
+		return solution, err
+	}
+
+	best := -1
+	err = walkSubsets(ctx, items, n/2, n, func(s int, _ uint64) bool {
+		if s > target {
+			return true
+		}
+		if j := sort.SearchInts(left.sums, target-s+1) - 1; j >= 0 {
+			best = max(best, s+left.sums[j])
+		}
+		return best < target
+	})
+	if err != nil || best < 0 {
+		return solution, err
+	}
+	solution.AchievedWeight = best
+
+	var masks []uint64
+	err = walkSubsets(ctx, items, n/2, n, func(s int, mask uint64) bool {
+		if s > best {
+			return true
+		}
+		lo := sort.SearchInts(left.sums, best-s)
+		hi := sort.SearchInts(left.sums, best-s+1)
+		if lo == hi {
+			return true
+		}
+		if solution.FirstCombination == nil {
+			solution.FirstSolutionTime = float64(time.Since(startAll).Microseconds()) / 1000
+			solution.FirstCombination = maskIndices(items, left.masks[lo]|mask)
+			solution.FirstWeight = best
+		}
+		for k := lo; k < hi; k++ {
+			masks = appendSmallest(masks, left.masks[k]|mask, limit)
+		}
+		solution.Omitted += hi - lo
+		return true
+	})
+
+	sort.Slice(masks, func(a, b int) bool { return masks[a] < masks[b] })
+	for _, mask := range masks {
+		solution.Combinations = append(solution.Combinations, maskIndices(items, mask))
+	}
+	solution.Omitted -= len(masks)
+	solution.AllSolutionsTime = float64(time.Since(startAll).Microseconds()) / 1000
+	return solution, err
+}
+
+// appendSmallest adds mask to masks, a max-heap of at most limit masks,
+// so that it always holds the limit smallest masks seen.
+func appendSmallest(masks []uint64, mask uint64, limit int) []uint64 {
+	if len(masks) < limit {
+		masks = append(masks, mask)
+		for i := len(masks) - 1; i > 0; {
+			parent := (i - 1) / 2
+			if masks[parent] >= masks[i] {
+				break
+			}
+			masks[parent], masks[i] = masks[i], masks[parent]
+			i = parent
+		}
+		return masks
+	}
+	if limit <= 0 || mask >= masks[0] {
+		return masks
+	}
+	masks[0] = mask
+	for i := 0; ; {
+		largest, l, r := i, 2*i+1, 2*i+2
+		if l < len(masks) && masks[l] > masks[largest] {
+			largest = l
+		}
+		if r < len(masks) && masks[r] > masks[largest] {
+			largest = r
+		}
+		if largest == i {
+			return masks
+		}
+		masks[i], masks[largest] = masks[largest], masks[i]
+		i = largest
+	}
+}
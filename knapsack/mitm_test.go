@@ -0,0 +1,18 @@
+package knapsack
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestMeetInTheMiddleRejectsTooManyItems(t *testing.T) {
+	items, target := randomInstance(rand.New(rand.NewSource(1)), maxMITMItems+1, 1000)
+	if _, err := MeetInTheMiddle(context.Background(), items, target); !errors.Is(err, errTooManyItems) {
+		t.Errorf("MeetInTheMiddle with %d items: error %v, want %v", len(items), err, errTooManyItems)
+	}
+	if _, err := StreamMeetInTheMiddle(context.Background(), items, target, 1); !errors.Is(err, errTooManyItems) {
+		t.Errorf("StreamMeetInTheMiddle with %d items: error %v, want %v", len(items), err, errTooManyItems)
+	}
+}
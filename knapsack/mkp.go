@@ -0,0 +1,181 @@
+package knapsack
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// MKPInstance is a multidimensional knapsack instance as stored in the
+// OR-Library mknap files.
+type MKPInstance struct {
+	Items   []ValuedItem
+	Problem ValueProblem
+}
+
+// ReadMKNAP reads a file in the OR-Library mknap format: the number of
+// instances, then for every instance the number of items n, the number of
+// constraints m and the optimal value (0 if unknown), followed by n
+// profits, an m×n weight matrix stored row by row and m capacities. All
+// numbers are whitespace-separated.
+func ReadMKNAP(filename string) ([]MKPInstance, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Split(bufio.ScanWords)
+	next := func() (int, error) {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return 0, err
+			}
+			return 0, fmt.Errorf("%s: unexpected end of file", filename)
+		}
+		// Some files write integral values as floats, e.g. "3800.0".
+		v, err := strconv.ParseFloat(scanner.Text(), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", filename, err)
+		}
+		return int(v), nil
+	}
+	nextAll := func(values []int) error {
+		for i := range values {
+			var err error
+			if values[i], err = next(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	count, err := next()
+	if err != nil {
+		return nil, err
+	}
+	instances := make([]MKPInstance, count)
+	for k := range instances {
+		header := make([]int, 3)
+		if err := nextAll(header); err != nil {
+			return nil, err
+		}
+		n, m := header[0], header[1]
+		if n < 1 || m < 1 {
+			return nil, fmt.Errorf("%s: instance %d has %d items and %d constraints", filename, k+1, n, m)
+		}
+
+		items := make([]ValuedItem, n)
+		for j := range items {
+			if items[j].Value, err = next(); err != nil {
+				return nil, err
+			}
+			items[j].Weights = make([]int, m)
+			items[j].Index = j
+		}
+		for i := 0; i < m; i++ {
+			for j := range items {
+				if items[j].Weights[i], err = next(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		capacities := make([]int, m)
+		if err := nextAll(capacities); err != nil {
+			return nil, err
+		}
+
+		instances[k] = MKPInstance{
+			Items:   items,
+			Problem: ValueProblem{ID: k + 1, Capacities: capacities, Optimum: header[2]},
+		}
+	}
+	return instances, nil
+}
+
+// pseudoUtility is the repair heuristic of Chu and Beasley: items are
+// ranked by value per unit of capacity they consume, summed over all
+// constraints, so the ranking needs no dual solution of the LP relaxation.
+type pseudoUtility struct {
+	// order lists item positions by increasing utility.
+	order []int
+}
+
+func newPseudoUtility(items []ValuedItem, capacities []int) pseudoUtility {
+	utility := make([]float64, len(items))
+	for j, item := range items {
+		load := 0.0
+		for i, w := range item.Weights {
+			load += float64(w) / float64(max(capacities[i], 1))
+		}
+		utility[j] = float64(item.Value) / load
+	}
+	order := make([]int, len(items))
+	for j := range order {
+		order[j] = j
+	}
+	sort.SliceStable(order, func(a, b int) bool { return utility[order[a]] < utility[order[b]] })
+	return pseudoUtility{order: order}
+}
+
+// repair drops the selected items with the lowest utility until c is
+// feasible and then adds the unselected items with the highest utility
+// that still fit. It returns the repaired chromosome and the number of
+// genes it changed; the genome of c is not modified.
+func (p pseudoUtility) repair(c ValueChromosome, items []ValuedItem, capacities []int) (ValueChromosome, int) {
+	genes := c.Genes.Clone()
+	weights := append([]int(nil), c.Weights...)
+	value := c.Value
+	changed := 0
+
+	for _, j := range p.order {
+		if Excess(weights, capacities) == 0 {
+			break
+		}
+		if genes.Get(j) {
+			genes.Flip(j)
+			value -= items[j].Value
+			for i, w := range items[j].Weights {
+				weights[i] -= w
+			}
+			changed++
+		}
+	}
+
+	for k := len(p.order) - 1; k >= 0; k-- {
+		j := p.order[k]
+		if genes.Get(j) || !fits(weights, items[j].Weights, capacities) {
+			continue
+		}
+		genes.Flip(j)
+		value += items[j].Value
+		for i, w := range items[j].Weights {
+			weights[i] += w
+		}
+		changed++
+	}
+
+	if changed == 0 {
+		return c, 0
+	}
+	excess := Excess(weights, capacities)
+	return ValueChromosome{
+		Genes:   genes,
+		Value:   value,
+		Weights: weights,
+		Excess:  excess,
+		Fitness: valueFitness(value, excess),
+	}, changed
+}
+
+func fits(weights, extra, capacities []int) bool {
+	for i, w := range extra {
+		if weights[i]+w > capacities[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+package knapsack
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+var errNotSubsetSum = errors.New("knapsack: problem is not a *SubsetSum")
+
+// SubsetSum is the Labwork1 problem as an optimize.Problem: choose items
+// so that their total weight is as close to Target as possible.
+type SubsetSum struct {
+	Items  []Item
+	Target int
+}
+
+func (p *SubsetSum) Encoding() optimize.Encoding      { return optimize.Binary }
+func (p *SubsetSum) Dim() int                         { return len(p.Items) }
+func (p *SubsetSum) Bounds() (lower, upper []float64) { return nil, nil }
+func (p *SubsetSum) Direction() optimize.Direction    { return optimize.Minimize }
+
+func (p *SubsetSum) Evaluate(genes []bool) float64 {
+	c := CalculateFitness(Chromosome{Genes: GenomeFromBools(genes)}, p.Items, p.Target)
+	return float64(c.Fitness)
+}
+
+// genesFromIndices converts item indices as reported in Solution back to
+// a gene vector over items.
+func genesFromIndices(items []Item, indices []int) []bool {
+	positions := make(map[int]int, len(items))
+	for i, item := range items {
+		positions[item.Index] = i
+	}
+	genes := make([]bool, len(items))
+	for _, index := range indices {
+		genes[positions[index]] = true
+	}
+	return genes
+}
+
+// BruteForceOptimizer adapts BruteForce to optimize.Optimizer. Only
+// subsets not exceeding the target are considered.
+type BruteForceOptimizer struct{}
+
+func (BruteForceOptimizer) Optimize(ctx context.Context, p optimize.Problem[[]bool]) (optimize.Result[[]bool], error) {
+	ss, ok := p.(*SubsetSum)
+	if !ok {
+		return optimize.Result[[]bool]{}, errNotSubsetSum
+	}
+
+	start := time.Now()
+	solution, err := BruteForce(ctx, ss.Items, ss.Target)
+	var best []int
+	if len(solution.Combinations) > 0 {
+		best = solution.Combinations[0]
+	}
+	genes := genesFromIndices(ss.Items, best)
+
+	result := optimize.Result[[]bool]{
+		Best:        genes,
+		Value:       ss.Evaluate(genes),
+		Evaluations: 1 << uint(len(ss.Items)),
+		Iterations:  1,
+		Reason:      optimize.Exhausted,
+		Elapsed:     time.Since(start),
+	}
+	if err != nil {
+		result.Reason = optimize.Cancelled
+	}
+	return result, err
+}
+
+// GAOptimizer adapts GeneticAlgorithm to optimize.Optimizer.
+type GAOptimizer struct {
+	Config GAConfig
+}
+
+func (o GAOptimizer) Optimize(ctx context.Context, p optimize.Problem[[]bool]) (optimize.Result[[]bool], error) {
+	ss, ok := p.(*SubsetSum)
+	if !ok {
+		return optimize.Result[[]bool]{}, errNotSubsetSum
+	}
+	best, report := GeneticAlgorithm(ctx, ss.Items, Problem{Target: ss.Target}, o.Config)
+	genes := best.Genes.Bools()
+	// Fitness depends on the constraint, so the value is recomputed on the
+	// scale of SubsetSum.Evaluate like that of BruteForceOptimizer.
+	result := optimize.Result[[]bool]{
+		Best:        genes,
+		Value:       ss.Evaluate(genes),
+		Evaluations: report.Evaluations,
+		Iterations:  report.Generations,
+		Reason:      report.Reason.Generic(),
+		Elapsed:     report.WallTime,
+	}
+	if report.Reason == Cancelled {
+		return result, ctx.Err()
+	}
+	return result, nil
+}
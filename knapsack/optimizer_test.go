@@ -0,0 +1,24 @@
+package knapsack
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+func TestGAOptimizerValueIgnoresConstraint(t *testing.T) {
+	items, target := randomInstance(rand.New(rand.NewSource(1)), 30, 1000)
+	ss := &SubsetSum{Items: items, Target: target}
+	for _, constraint := range []Constraint{Distance{}, Reject{}, Repair{}, StaticPenalty{Coefficient: 3}} {
+		config := DefaultGAConfig()
+		config.PopulationSize = 50
+		config.Constraint = constraint
+		result, err := GAOptimizer{Config: config}.Optimize(context.Background(), ss)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := ss.Evaluate(result.Best); result.Value != want {
+			t.Errorf("%s: value %g, want %g", constraint, result.Value, want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package knapsack
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// bruteChunk is the result of enumerating one range of masks.
+type bruteChunk struct {
+	best      int
+	solutions [][]int
+
+	// first is when the chunk first improved on a positive weight.
+	first            time.Time
+	firstCombination []int
+	firstWeight      int
+	found            bool
+}
+
+// ParallelBruteForce solves the same problem as BruteForce by splitting
+// the masks into chunks of cancelCheckInterval and handing them to one
+// worker per CPU. Workers share the best weight found so far, so that no
+// chunk keeps combinations lighter than it, and the chunks are merged in
+// mask order, so the result is the same as that of BruteForce.
+// FirstSolutionTime is the earliest improvement made by any worker.
+func ParallelBruteForce(ctx context.Context, items []Item, target int) (Solution, error) {
+	startAll := time.Now()
+	total := 1 << uint(len(items))
+	chunks := (total + cancelCheckInterval - 1) / cancelCheckInterval
+	results := make([]bruteChunk, chunks)
+	done := make([]bool, chunks)
+
+	var shared atomic.Int64
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < min(runtime.GOMAXPROCS(0), chunks); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				c := int(next.Add(1) - 1)
+				if c >= chunks || ctx.Err() != nil {
+					return
+				}
+				from := c * cancelCheckInterval
+				to := min(from+cancelCheckInterval, total)
+				results[c] = bruteForceRange(items, target, from, to, &shared)
+				done[c] = true
+			}
+		}()
+	}
+	wg.Wait()
+
+	maxWeight := 0
+	for c := range results {
+		if done[c] {
+			maxWeight = max(maxWeight, results[c].best)
+		}
+	}
+	solution := Solution{AchievedWeight: maxWeight, Combinations: [][]int{}}
+	var first time.Time
+	for c := range results {
+		r := &results[c]
+		if !done[c] {
+			continue
+		}
+		if r.best == maxWeight {
+			solution.Combinations = append(solution.Combinations, r.solutions...)
+		}
+		if r.found && (first.IsZero() || r.first.Before(first)) {
+			first = r.first
+			solution.FirstCombination = r.firstCombination
+			solution.FirstWeight = r.firstWeight
+		}
+	}
+
+	if !first.IsZero() {
+		solution.FirstSolutionTime = float64(first.Sub(startAll).Microseconds()) / 1000
+	}
+	solution.AllSolutionsTime = float64(time.Since(startAll).Microseconds()) / 1000
+	return solution, ctx.Err()
+}
+
+// bruteForceRange enumerates the masks in [from, to) like BruteForce,
+// starting from the best weight in shared and raising it as it improves.
+func bruteForceRange(items []Item, target, from, to int, shared *atomic.Int64) bruteChunk {
+	n := len(items)
+	r := bruteChunk{best: int(shared.Load())}
+
+	for mask := from; mask < to; mask++ {
+		if mask%4096 == 0 {
+			if s := int(shared.Load()); s > r.best {
+				r.best = s
+				r.solutions = nil
+			}
+		}
+
+		currentWeight := 0
+		var currentCombination []int
+
+		for i := 0; i < n; i++ {
+			if mask&(1<<uint(i)) != 0 {
+				currentWeight += items[i].Weight
+				currentCombination = append(currentCombination, items[i].Index)
+			}
+
+			if currentWeight > target {
+				break
+			}
+		}
+
+		if currentWeight > target || currentWeight < r.best {
+			continue
+		}
+
+		if currentWeight > r.best {
+			r.best = currentWeight
+			r.solutions = [][]int{currentCombination}
+			if !r.found {
+				r.first = time.Now()
+				r.firstCombination = currentCombination
+				r.firstWeight = currentWeight
+				r.found = true
+			}
+			for s := shared.Load(); int64(currentWeight) > s; s = shared.Load() {
+				if shared.CompareAndSwap(s, int64(currentWeight)) {
+					break
+				}
+			}
+		} else {
+			r.solutions = append(r.solutions, currentCombination)
+		}
+	}
+	return r
+}
@@ -0,0 +1,84 @@
+package knapsack
+
+import (
+	"time"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/optimize"
+)
+
+// TerminationReason tells why GeneticAlgorithm stopped.
+type TerminationReason int
+
+const (
+	MaxGenerations TerminationReason = iota
+	ZeroFitness
+	NoImprovement
+	TimeExceeded
+	Cancelled
+)
+
+// String returns the name written to ga_solutions.csv.
+func (r TerminationReason) String() string {
+	switch r {
+	case MaxGenerations:
+		return "max_generations"
+	case ZeroFitness:
+		return "zero_fitness"
+	case NoImprovement:
+		return "no_improvement"
+	case TimeExceeded:
+		return "time_exceeded"
+	case Cancelled:
+		return "cancelled"
+	}
+	return "unknown"
+}
+
+// Generic maps r to the corresponding optimize.TerminationReason.
+func (r TerminationReason) Generic() optimize.TerminationReason {
+	switch r {
+	case ZeroFitness:
+		return optimize.TargetReached
+	case NoImprovement:
+		return optimize.NoImprovement
+	case TimeExceeded:
+		return optimize.TimeExceeded
+	case Cancelled:
+		return optimize.Cancelled
+	}
+	return optimize.MaxIterations
+}
+
+// GenerationStats describes the population after one generation.
+type GenerationStats struct {
+	Generation  int
+	BestFitness int
+	BestWeight  int
+	MeanFitness float64
+}
+
+// RunReport describes a single GeneticAlgorithm run.
+type RunReport struct {
+	Generations int
+	Reason      TerminationReason
+	History     []GenerationStats
+	Evaluations int
+	// GeneUpdates counts the item weights added or subtracted while
+	// evaluating chromosomes. Offspring are evaluated incrementally from
+	// their parents, so this is the actual cost of the evaluations.
+	GeneUpdates int
+	WallTime    time.Duration
+}
+
+func generationStats(generation int, population []Chromosome, best Chromosome) GenerationStats {
+	total := 0
+	for _, c := range population {
+		total += c.Fitness
+	}
+	return GenerationStats{
+		Generation:  generation,
+		BestFitness: best.Fitness,
+		BestWeight:  best.Weight,
+		MeanFitness: float64(total) / float64(len(population)),
+	}
+}
@@ -0,0 +1,242 @@
+package knapsack
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// ValuedItem is an item of the general 0/1 knapsack problem. Weights has
+// one entry per capacity constraint.
+type ValuedItem struct {
+	Value   int
+	Weights []int
+	Index   int
+}
+
+// ValueProblem asks for the subset of a valued item vector with the
+// largest total value whose weight does not exceed Capacities in any
+// dimension.
+type ValueProblem struct {
+	ID          int
+	Capacities  []int
+	BruteTimeMs float64
+	// Optimum is the best known value, or 0 if it is unknown.
+	Optimum int
+}
+
+// Gap returns how far value falls short of p.Optimum in percent of it,
+// and false if the optimum is unknown.
+func (p ValueProblem) Gap(value int) (float64, bool) {
+	if p.Optimum <= 0 {
+		return 0, false
+	}
+	return 100 * float64(p.Optimum-value) / float64(p.Optimum), true
+}
+
+// Totals returns the total value and per-dimension weights of the items
+// selected by genes.
+func Totals(items []ValuedItem, genes Genome) (value int, weights []int) {
+	weights = make([]int, len(items[0].Weights))
+	genes.ForEachSet(func(i int) {
+		value += items[i].Value
+		for d, w := range items[i].Weights {
+			weights[d] += w
+		}
+	})
+	return value, weights
+}
+
+// Excess returns the total weight by which weights exceed capacities.
+func Excess(weights, capacities []int) int {
+	excess := 0
+	for d, w := range weights {
+		if w > capacities[d] {
+			excess += w - capacities[d]
+		}
+	}
+	return excess
+}
+
+// ReadValuedItems reads item vectors in the format written by
+// WriteValuedItems and groups them by vector.
+func ReadValuedItems(filename string) ([][]ValuedItem, error) {
+	records, err := readRecords(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var vectors [][]ValuedItem
+	for i, record := range records {
+		if len(record) < 4 {
+			return nil, fmt.Errorf("invalid number of fields in row %d", i+2)
+		}
+		values, err := atoiAll(record)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		vectorID := values[0]
+		if vectorID == len(vectors)+1 {
+			vectors = append(vectors, nil)
+		} else if vectorID != len(vectors) {
+			return nil, fmt.Errorf("row %d: vector %d out of order", i+2, vectorID)
+		}
+		vectors[vectorID-1] = append(vectors[vectorID-1], ValuedItem{
+			Index:   values[1],
+			Value:   values[2],
+			Weights: values[3:],
+		})
+	}
+	return vectors, nil
+}
+
+// WriteValuedItems writes one item per row as VectorID,Index,Value
+// followed by one Weight column per dimension, after a comment line
+// recording the seed the items were generated with.
+func WriteValuedItems(filename string, vectors [][]ValuedItem, seed int64) error {
+	header := []string{"VectorID", "Index", "Value"}
+	if len(vectors) > 0 && len(vectors[0]) > 0 {
+		header = append(header, weightColumns("Weight", len(vectors[0][0].Weights))...)
+	}
+
+	var records [][]string
+	for v, items := range vectors {
+		for _, item := range items {
+			record := []string{strconv.Itoa(v + 1), strconv.Itoa(item.Index), strconv.Itoa(item.Value)}
+			for _, w := range item.Weights {
+				record = append(record, strconv.Itoa(w))
+			}
+			records = append(records, record)
+		}
+	}
+	return writeRecords(filename, seed, header, records)
+}
+
+// ReadValueProblems reads capacities in the format written by
+// WriteValueProblems and groups them by vector.
+func ReadValueProblems(filename string) ([][]ValueProblem, error) {
+	records, err := readRecords(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems [][]ValueProblem
+	for i, record := range records {
+		if len(record) < 3 {
+			return nil, fmt.Errorf("invalid number of fields in row %d", i+2)
+		}
+		values, err := atoiAll(record)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", i+2, err)
+		}
+		vectorID := values[0]
+		if vectorID == len(problems)+1 {
+			problems = append(problems, nil)
+		} else if vectorID != len(problems) {
+			return nil, fmt.Errorf("row %d: vector %d out of order", i+2, vectorID)
+		}
+		problems[vectorID-1] = append(problems[vectorID-1], ValueProblem{
+			ID:         values[1],
+			Capacities: values[2:],
+		})
+	}
+	return problems, nil
+}
+
+// WriteValueProblems writes one problem per row as VectorID,ProblemID
+// followed by one Capacity column per dimension, after a comment line
+// recording the seed the problems were generated with.
+func WriteValueProblems(filename string, problems [][]ValueProblem, seed int64) error {
+	header := []string{"VectorID", "ProblemID"}
+	if len(problems) > 0 && len(problems[0]) > 0 {
+		header = append(header, weightColumns("Capacity", len(problems[0][0].Capacities))...)
+	}
+
+	var records [][]string
+	for v, group := range problems {
+		for _, p := range group {
+			record := []string{strconv.Itoa(v + 1), strconv.Itoa(p.ID)}
+			for _, c := range p.Capacities {
+				record = append(record, strconv.Itoa(c))
+			}
+			records = append(records, record)
+		}
+	}
+	return writeRecords(filename, seed, header, records)
+}
+
+// weightColumns names one column per dimension: "Weight" for a single
+// dimension, "Weight1", "Weight2", ... otherwise.
+func weightColumns(name string, dims int) []string {
+	if dims == 1 {
+		return []string{name}
+	}
+	columns := make([]string, dims)
+	for d := range columns {
+		columns[d] = name + strconv.Itoa(d+1)
+	}
+	return columns
+}
+
+// WriteSeed writes the "# seed: N" comment line that starts the CSV files
+// of generated instances and of GA results. Readers of these files skip
+// it.
+func WriteSeed(w io.Writer, seed int64) error {
+	_, err := fmt.Fprintf(w, "# seed: %d\n", seed)
+	return err
+}
+
+// readRecords reads a CSV file and drops its header row and the lines
+// starting with '#'.
+func readRecords(filename string) ([][]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > 0 {
+		records = records[1:]
+	}
+	return records, nil
+}
+
+// writeRecords writes the seed line, header and records.
+func writeRecords(filename string, seed int64, header []string, records [][]string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := WriteSeed(file, seed); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(file)
+	writer.Write(header)
+	writer.WriteAll(records)
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func atoiAll(fields []string) ([]int, error) {
+	values := make([]int, len(fields))
+	for i, field := range fields {
+		v, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
@@ -0,0 +1,194 @@
+package knapsack
+
+import (
+	"context"
+	"sort"
+)
+
+// ValueChromosome is a candidate solution of a ValueProblem. Fitness is
+// Value for feasible subsets; infeasible ones are scored by the
+// constraint, see ValueGA. Higher is better.
+type ValueChromosome struct {
+	Genes   Genome
+	Value   int
+	Weights []int
+	// Excess is the total weight by which Weights exceed the capacities.
+	Excess  int
+	Fitness int
+}
+
+// Feasible reports whether c fits all capacities.
+func (c ValueChromosome) Feasible() bool { return c.Excess == 0 }
+
+// ValueGA maximises the total value of items subject to
+// problem.Capacities in every dimension. It uses the same configuration
+// and run as GeneticAlgorithm, islands included. The constraint decides
+// the fitness of infeasible chromosomes: Reject ranks them below every
+// feasible one by their excess weight, Repair first repairs every
+// chromosome with the pseudo-utility heuristic, and the other constraints
+// subtract their fitness for the excess weight over a zero target from the
+// value, so Distance is a static penalty of one per unit of excess. A nil
+// constraint means Reject. A positive problem.BruteTimeMs limits the run
+// to twice the brute-force time.
+func ValueGA(ctx context.Context, items []ValuedItem, problem ValueProblem, config GAConfig) (ValueChromosome, RunReport) {
+	constraint := config.Constraint
+	if constraint == nil {
+		constraint = Reject{}
+	}
+	var utility *pseudoUtility
+	if _, ok := constraint.(Repair); ok {
+		u := newPseudoUtility(items, problem.Capacities)
+		utility = &u
+	}
+	newScorer := func() scorer[ValueChromosome] {
+		return &valueScorer{items: items, capacities: problem.Capacities, constraint: constraint, utility: utility}
+	}
+	return evolve(ctx, newScorer, len(items), problem.BruteTimeMs, config)
+}
+
+// valueScorer scores ValueChromosomes under a Constraint. utility is set
+// for Repair only.
+type valueScorer struct {
+	items      []ValuedItem
+	capacities []int
+	constraint Constraint
+	utility    *pseudoUtility
+}
+
+// repair repairs c if the constraint is Repair and sets its fitness. It
+// returns the number of genes changed by the repair.
+func (s *valueScorer) repair(c ValueChromosome) (ValueChromosome, int) {
+	changed := 0
+	if s.utility != nil {
+		c, changed = s.utility.repair(c, s.items, s.capacities)
+	}
+	return s.rescore(c), changed
+}
+
+func (s *valueScorer) evaluate(genes Genome) (ValueChromosome, int) {
+	c, changed := s.repair(EvaluateValue(genes, s.items, s.capacities))
+	return c, genes.Count() + changed
+}
+
+func (s *valueScorer) derive(parent ValueChromosome, genes Genome) (ValueChromosome, int) {
+	child, derived := DeriveValue(parent, genes, s.items, s.capacities)
+	c, repaired := s.repair(child)
+	return c, derived + repaired
+}
+
+func (s *valueScorer) genes(c ValueChromosome) Genome { return c.Genes }
+
+func (s *valueScorer) score(c ValueChromosome) float64 { return float64(c.Fitness) }
+
+// solved is always false: the optimal value is not known in general.
+func (s *valueScorer) solved(ValueChromosome) bool { return false }
+
+func (s *valueScorer) adapt(population []ValueChromosome) bool {
+	adaptive, ok := s.constraint.(AdaptiveConstraint)
+	if !ok {
+		return false
+	}
+	feasible := 0
+	for _, c := range population {
+		if c.Feasible() {
+			feasible++
+		}
+	}
+	s.constraint = adaptive.Adapt(float64(feasible) / float64(len(population)))
+	return true
+}
+
+func (s *valueScorer) rescore(c ValueChromosome) ValueChromosome {
+	switch {
+	case c.Excess == 0:
+		c.Fitness = c.Value
+	case isRejecting(s.constraint):
+		c.Fitness = valueFitness(c.Value, c.Excess)
+	default:
+		c.Fitness = c.Value - s.constraint.Fitness(c.Excess, 0)
+	}
+	return c
+}
+
+func (s *valueScorer) stats(generation int, population []ValueChromosome, best ValueChromosome) GenerationStats {
+	return valueGenerationStats(generation, population, best)
+}
+
+func (s *valueScorer) betterFitness(a, b int) bool { return a > b }
+
+// isRejecting reports whether c ranks infeasible value chromosomes below
+// all feasible ones.
+func isRejecting(c Constraint) bool {
+	switch c.(type) {
+	case Reject, Repair:
+		return true
+	}
+	return false
+}
+
+// EvaluateValue computes value, weights and fitness of genes from scratch.
+// The fitness ranks infeasible subsets below all feasible ones, as under
+// Reject.
+func EvaluateValue(genes Genome, items []ValuedItem, capacities []int) ValueChromosome {
+	c := ValueChromosome{Genes: genes}
+	c.Value, c.Weights = Totals(items, genes)
+	c.Excess = Excess(c.Weights, capacities)
+	c.Fitness = valueFitness(c.Value, c.Excess)
+	return c
+}
+
+// DeriveValue evaluates genes as a variant of the evaluated parent,
+// visiting only the genes in which they differ, and returns their number
+// along with the child.
+func DeriveValue(parent ValueChromosome, genes Genome, items []ValuedItem, capacities []int) (ValueChromosome, int) {
+	child := ValueChromosome{
+		Genes:   genes,
+		Value:   parent.Value,
+		Weights: append([]int(nil), parent.Weights...),
+	}
+	changed := 0
+	genes.forEachDiff(parent.Genes, func(i int) {
+		sign := -1
+		if genes.Get(i) {
+			sign = 1
+		}
+		child.Value += sign * items[i].Value
+		for d, w := range items[i].Weights {
+			child.Weights[d] += sign * w
+		}
+		changed++
+	})
+	child.Excess = Excess(child.Weights, capacities)
+	child.Fitness = valueFitness(child.Value, child.Excess)
+	return child, changed
+}
+
+// valueFitness is value for feasible subsets and minus the excess weight
+// otherwise.
+func valueFitness(value, excess int) int {
+	if excess > 0 {
+		return -excess
+	}
+	return value
+}
+
+func valueGenerationStats(generation int, population []ValueChromosome, best ValueChromosome) GenerationStats {
+	total := 0
+	for _, c := range population {
+		total += c.Fitness
+	}
+	return GenerationStats{
+		Generation:  generation,
+		BestFitness: best.Fitness,
+		BestWeight:  best.Weights[0],
+		MeanFitness: float64(total) / float64(len(population)),
+	}
+}
+
+// ValueSolutionIndices returns the sorted item indices selected by c.
+func ValueSolutionIndices(c ValueChromosome, items []ValuedItem) []int {
+	indices := make([]int, 0, c.Genes.Count())
+	c.Genes.ForEachSet(func(i int) { indices = append(indices, items[i].Index) })
+	sort.Ints(indices)
+	return indices
+}
@@ -0,0 +1,75 @@
+package knapsack
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/alexe14ernyakov/BioinspiredAlgorithms/ga"
+)
+
+func TestValueGAConstraints(t *testing.T) {
+	items := GenerateValuedItems(rand.New(rand.NewSource(1)), 40, 2, 1000, 1000)
+	problem := ValueProblem{ID: 1, Capacities: GenerateCapacities(items, 0.3)}
+	tests := []struct {
+		constraint Constraint
+		// fitness is the expected fitness of an infeasible chromosome.
+		fitness func(c ValueChromosome) int
+	}{
+		{Reject{}, func(c ValueChromosome) int { return -c.Excess }},
+		{Repair{}, nil},
+		{Distance{}, func(c ValueChromosome) int { return c.Value - c.Excess }},
+		{StaticPenalty{Coefficient: 0.5}, func(c ValueChromosome) int {
+			return c.Value - int(math.Ceil(0.5*float64(c.Excess)))
+		}},
+	}
+	for _, tt := range tests {
+		config := DefaultGAConfig()
+		config.PopulationSize = 100
+		config.MaxNoImprovement = 10
+		config.Constraint = tt.constraint
+		best, _ := ValueGA(context.Background(), items, problem, config)
+
+		if value, weights := Totals(items, best.Genes); value != best.Value || Excess(weights, problem.Capacities) != best.Excess {
+			t.Errorf("%s: value %d and excess %d, genes give %d and %d",
+				tt.constraint, best.Value, best.Excess, value, Excess(weights, problem.Capacities))
+		}
+		switch {
+		case best.Feasible():
+			if best.Fitness != best.Value {
+				t.Errorf("%s: feasible fitness %d, want value %d", tt.constraint, best.Fitness, best.Value)
+			}
+		case tt.fitness == nil:
+			t.Errorf("%s: infeasible solution with excess %d", tt.constraint, best.Excess)
+		case best.Fitness != tt.fitness(best):
+			t.Errorf("%s: fitness %d, want %d", tt.constraint, best.Fitness, tt.fitness(best))
+		}
+	}
+}
+
+func TestValueGAIslands(t *testing.T) {
+	items := GenerateValuedItems(rand.New(rand.NewSource(2)), 40, 2, 1000, 1000)
+	problem := ValueProblem{ID: 1, Capacities: GenerateCapacities(items, 0.5)}
+	config := DefaultGAConfig()
+	config.PopulationSize = 200
+	config.MaxNoImprovement = 20
+	config.Constraint = Repair{}
+	config.Migration = ga.Migration{Islands: 4, Interval: 5, Migrants: 2, Topology: ga.Ring{}}
+	config.Seed = 3
+
+	want, wantReport := ValueGA(context.Background(), items, problem, config)
+	if !want.Feasible() {
+		t.Fatalf("infeasible solution with excess %d under repair", want.Excess)
+	}
+	for _, stats := range wantReport.History {
+		if stats.BestFitness > want.Fitness {
+			t.Fatalf("generation %d has best fitness %d above the result %d", stats.Generation, stats.BestFitness, want.Fitness)
+		}
+	}
+	got, report := ValueGA(context.Background(), items, problem, config)
+	if !got.Genes.Equal(want.Genes) || report.Evaluations != wantReport.Evaluations {
+		t.Errorf("second run: value %d after %d evaluations, want %d after %d",
+			got.Value, report.Evaluations, want.Value, wantReport.Evaluations)
+	}
+}
@@ -0,0 +1,50 @@
+package knapsack
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// offspringBlock is the number of offspring pairs bred from one random
+// source. The sources belong to the blocks rather than to the workers, so
+// a run depends neither on goroutine scheduling nor on the number of
+// workers.
+const offspringBlock = 64
+
+// blockSources returns one source per block of offspringBlock out of n
+// offspring pairs, seeded from r in block order.
+func blockSources(r *rand.Rand, n int) []*rand.Rand {
+	sources := make([]*rand.Rand, (n+offspringBlock-1)/offspringBlock)
+	for b := range sources {
+		sources[b] = rand.New(rand.NewSource(r.Int63()))
+	}
+	return sources
+}
+
+// forEachBlock splits [0, n) into blocks of offspringBlock, one per
+// source, and calls fn with the index of its worker, the source of the
+// block and its bounds for each block. Up to workers goroutines handle
+// contiguous runs of blocks concurrently.
+func forEachBlock(n int, sources []*rand.Rand, workers int, fn func(w int, r *rand.Rand, from, to int)) {
+	block := func(w, b int) {
+		fn(w, sources[b], b*offspringBlock, min((b+1)*offspringBlock, n))
+	}
+	workers = min(workers, len(sources))
+	if workers <= 1 {
+		for b := range sources {
+			block(0, b)
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := len(sources) * w / workers; b < len(sources)*(w+1)/workers; b++ {
+				block(w, b)
+			}
+		}()
+	}
+	wg.Wait()
+}
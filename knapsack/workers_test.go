@@ -0,0 +1,40 @@
+package knapsack
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+func TestWorkersDoNotChangeResults(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	items, target := randomInstance(r, 40, 1<<16)
+	problem := Problem{ID: 1, Target: target}
+	valued := GenerateValuedItems(r, 40, 2, 1000, 1000)
+	valueProblem := ValueProblem{ID: 1, Capacities: GenerateCapacities(valued, 0.5)}
+
+	config := DefaultGAConfig()
+	config.PopulationSize = 300
+	config.MaxNoImprovement = 20
+	config.Seed = 42
+
+	want, wantReport := GeneticAlgorithm(context.Background(), items, problem, config)
+	wantValue, wantValueReport := ValueGA(context.Background(), valued, valueProblem, config)
+	for _, workers := range []int{2, 3, 4, 8} {
+		config.Workers = workers
+		got, report := GeneticAlgorithm(context.Background(), items, problem, config)
+		if !got.Genes.Equal(want.Genes) || report.Generations != wantReport.Generations ||
+			report.GeneUpdates != wantReport.GeneUpdates {
+			t.Errorf("GeneticAlgorithm with %d workers: weight %d, %d generations, %d gene updates, want %d, %d, %d",
+				workers, got.Weight, report.Generations, report.GeneUpdates,
+				want.Weight, wantReport.Generations, wantReport.GeneUpdates)
+		}
+		gotValue, valueReport := ValueGA(context.Background(), valued, valueProblem, config)
+		if !gotValue.Genes.Equal(wantValue.Genes) || valueReport.Generations != wantValueReport.Generations ||
+			valueReport.GeneUpdates != wantValueReport.GeneUpdates {
+			t.Errorf("ValueGA with %d workers: value %d, %d generations, %d gene updates, want %d, %d, %d",
+				workers, gotValue.Value, valueReport.Generations, valueReport.GeneUpdates,
+				wantValue.Value, wantValueReport.Generations, wantValueReport.GeneUpdates)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Package optimize defines the problem and optimizer abstractions shared by
+// the genetic algorithms, the fish school search and the exact solvers.
+package optimize
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+type Direction int
+
+const (
+	Minimize Direction = iota
+	Maximize
+)
+
+// Better reports whether value a is strictly better than b. NaN, which an
+// objective returns where it is undefined, is worse than any number.
+func (d Direction) Better(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return !math.IsNaN(a)
+	}
+	if d == Maximize {
+		return a > b
+	}
+	return a < b
+}
+
+func (d Direction) String() string {
+	if d == Maximize {
+		return "max"
+	}
+	return "min"
+}
+
+// ParseDirection parses "min"/"minimize" or "max"/"maximize".
+func ParseDirection(s string) (Direction, error) {
+	switch s {
+	case "min", "minimize":
+		return Minimize, nil
+	case "max", "maximize":
+		return Maximize, nil
+	}
+	return Minimize, fmt.Errorf("unknown optimization direction %q", s)
+}
+
+type Encoding int
+
+const (
+	Real Encoding = iota
+	Binary
+)
+
+func (e Encoding) String() string {
+	if e == Binary {
+		return "binary"
+	}
+	return "real"
+}
+
+type TerminationReason int
+
+const (
+	MaxIterations TerminationReason = iota
+	NoImprovement
+	TargetReached
+	TimeExceeded
+	Exhausted
+	Cancelled
+	MaxEvaluations
+)
+
+func (r TerminationReason) String() string {
+	switch r {
+	case MaxIterations:
+		return "max_iterations"
+	case NoImprovement:
+		return "no_improvement"
+	case TargetReached:
+		return "target_reached"
+	case TimeExceeded:
+		return "time_exceeded"
+	case Exhausted:
+		return "exhausted"
+	case Cancelled:
+		return "cancelled"
+	case MaxEvaluations:
+		return "max_evaluations"
+	}
+	return "unknown"
+}
+
+// Problem describes what is optimized. S is the solution encoding:
+// []float64 for Real problems and []bool for Binary ones.
+type Problem[S any] interface {
+	Encoding() Encoding
+	Dim() int
+	// Bounds returns per-dimension bounds of a Real problem and nil
+	// slices for a Binary one.
+	Bounds() (lower, upper []float64)
+	Direction() Direction
+	Evaluate(s S) float64
+}
+
+type Result[S any] struct {
+	Best        S
+	Value       float64
+	Evaluations int
+	Iterations  int
+	Reason      TerminationReason
+	Elapsed     time.Duration
+}
+
+// Optimizer searches a Problem. When ctx is cancelled it returns the best
+// solution found so far with Reason set to Cancelled and ctx.Err().
+type Optimizer[S any] interface {
+	Optimize(ctx context.Context, p Problem[S]) (Result[S], error)
+}
+
+// RealProblem is a Problem over a box-bounded real vector.
+type RealProblem struct {
+	F     func(x []float64) float64
+	Lower []float64
+	Upper []float64
+	Dir   Direction
+}
+
+// NewRealProblem returns a dim-dimensional problem with the same bounds in
+// every dimension.
+func NewRealProblem(f func(x []float64) float64, dim int, min, max float64, dir Direction) *RealProblem {
+	p := &RealProblem{
+		F:     f,
+		Lower: make([]float64, dim),
+		Upper: make([]float64, dim),
+		Dir:   dir,
+	}
+	for i := 0; i < dim; i++ {
+		p.Lower[i] = min
+		p.Upper[i] = max
+	}
+	return p
+}
+
+func (p *RealProblem) Encoding() Encoding               { return Real }
+func (p *RealProblem) Dim() int                         { return len(p.Lower) }
+func (p *RealProblem) Bounds() (lower, upper []float64) { return p.Lower, p.Upper }
+func (p *RealProblem) Direction() Direction             { return p.Dir }
+func (p *RealProblem) Evaluate(x []float64) float64     { return p.F(x) }
+
+// Counter wraps an objective and counts its evaluations.
+type Counter[S any] struct {
+	F     func(S) float64
+	Count int
+}
+
+func (c *Counter[S]) Evaluate(s S) float64 {
+	c.Count++
+	return c.F(s)
+}
+
+// ResolveSeed returns seed, or a seed taken from the clock if seed is
+// zero. Command-line tools resolve their -seed flag with it and record
+// the result, so that every run can be repeated.
+func ResolveSeed(seed int64) int64 {
+	if seed != 0 {
+		return seed
+	}
+	return time.Now().UnixNano()
+}